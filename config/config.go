@@ -1,33 +1,147 @@
 package config
 
-func LoadConfig() DeployConfig {
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+func LoadConfig() (DeployConfig, error) {
+	base, err := LoadConfigFile(getEnv("CONFIG_FILE", ""))
+	if err != nil {
+		return DeployConfig{}, err
+	}
+
+	extraFiles := ParseExtraFilesFromEnv("EXTRA_FILES")
+	if extraFiles == nil {
+		extraFiles = base.ExtraFiles
+	}
+
+	env := &envReader{}
+
+	cfg := DeployConfig{
+		SSHHost:               getEnv("SSH_HOST", base.SSHHost),
+		SSHPort:               getEnv("SSH_PORT", base.SSHPort),
+		SSHUser:               getEnv("SSH_USER", base.SSHUser),
+		SSHKey:                getEnv("SSH_KEY", base.SSHKey),
+		SSHKeyPassphrase:      getEnv("SSH_KEY_PASSPHRASE", base.SSHKeyPassphrase),
+		SSHCertificate:        getEnv("SSH_CERTIFICATE", base.SSHCertificate),
+		SSHAgentSocket:        getEnv("SSH_AUTH_SOCK", base.SSHAgentSocket),
+		SSHAuthMethods:        splitCommas(splitEnv("SSH_AUTH_METHODS", base.SSHAuthMethods)),
+		SSHKnownHosts:         getEnv("SSH_KNOWN_HOSTS", base.SSHKnownHosts),
+		SSHFingerprint:        getEnv("SSH_FINGERPRINT", base.SSHFingerprint),
+		SSHHostCA:             getEnv("SSH_HOST_CA", base.SSHHostCA),
+		SSHRevokedKeys:        getEnv("SSH_REVOKED_KEYS", base.SSHRevokedKeys),
+		SSHTimeout:            getEnv("SSH_TIMEOUT", base.SSHTimeout),
+		SSHConnectRetries:     env.getInt("SSH_CONNECT_RETRIES", base.SSHConnectRetries),
+		SSHConnectRetryDelay:  getEnv("SSH_CONNECT_RETRY_DELAY", base.SSHConnectRetryDelay),
+		SSHKeepaliveInterval:  getEnv("SSH_KEEPALIVE_INTERVAL", base.SSHKeepaliveInterval),
+		SSHKeepaliveMaxMissed: env.getInt("SSH_KEEPALIVE_MAX_MISSED", base.SSHKeepaliveMaxMissed),
+		SSHJumpHosts:          base.SSHJumpHosts,
+		SSHJumpKey:            getEnv("SSH_JUMP_KEY", base.SSHJumpKey),
+		SSHJumpKeyPassphrase:  getEnv("SSH_JUMP_KEY_PASSPHRASE", base.SSHJumpKeyPassphrase),
+		SSHJumpCertificate:    getEnv("SSH_JUMP_CERTIFICATE", base.SSHJumpCertificate),
+		SSHJumpKnownHosts:     getEnv("SSH_JUMP_KNOWN_HOSTS", base.SSHJumpKnownHosts),
+		SSHJumpFingerprint:    getEnv("SSH_JUMP_FINGERPRINT", base.SSHJumpFingerprint),
+		ProjectPath:           getEnv("PROJECT_PATH", base.ProjectPath),
+		DeployFile:            getEnv("DEPLOY_FILE", base.DeployFile),
+		ExtraFiles:            extraFiles,
+		TransferMethod:        getEnv("TRANSFER_METHOD", base.TransferMethod),
+		UploadConcurrency:     env.getInt("UPLOAD_CONCURRENCY", base.UploadConcurrency),
+		SkipUnchanged:         env.getBool("SKIP_UNCHANGED", base.SkipUnchanged),
+		SyncDelete:            env.getBool("SYNC_DELETE", base.SyncDelete),
+		Mode:                  getEnv("MODE", base.Mode),
+		StackName:             getEnv("STACK_NAME", base.StackName),
+		StackDeployTimeout:    getEnv("STACK_DEPLOY_TIMEOUT", base.StackDeployTimeout),
+		ComposePull:           env.getBool("COMPOSE_PULL", base.ComposePull),
+		ComposeBuild:          env.getBool("COMPOSE_BUILD", base.ComposeBuild),
+		ComposeNoDeps:         env.getBool("COMPOSE_NO_DEPS", base.ComposeNoDeps),
+		ComposeTargetServices: splitEnv("COMPOSE_TARGET_SERVICES", base.ComposeTargetServices),
+		ComposeStrategy:       getEnv("COMPOSE_STRATEGY", base.ComposeStrategy),
+		ComposeHealthTimeout:  getEnv("COMPOSE_HEALTH_TIMEOUT", base.ComposeHealthTimeout),
+		ComposePullTimeout:    getEnv("COMPOSE_PULL_TIMEOUT", base.ComposePullTimeout),
+		ComposeUpTimeout:      getEnv("COMPOSE_UP_TIMEOUT", base.ComposeUpTimeout),
+		ComposeLogTail:        env.getInt("COMPOSE_LOG_TAIL", base.ComposeLogTail),
+		StackTaskHistory:      env.getInt("STACK_TASK_HISTORY", base.StackTaskHistory),
+		DockerNetwork:         getEnv("DOCKER_NETWORK", base.DockerNetwork),
+		DockerNetworkDriver:   getEnv("DOCKER_NETWORK_DRIVER", base.DockerNetworkDriver),
+		DockerNetworkAttach:   env.getBool("DOCKER_NETWORK_ATTACHABLE", base.DockerNetworkAttach),
+		DockerPrune:           getEnv("DOCKER_PRUNE", base.DockerPrune),
+		RegistryHost:          getEnv("REGISTRY_HOST", base.RegistryHost),
+		RegistryUser:          getEnv("REGISTRY_USER", base.RegistryUser),
+		RegistryPass:          getEnv("REGISTRY_PASS", base.RegistryPass),
+		EnableRollback:        env.getBool("ENABLE_ROLLBACK", base.EnableRollback),
+		RollbackOnCancel:      env.getBool("ROLLBACK_ON_CANCEL", base.RollbackOnCancel),
+		EnvVars:               getEnv("ENV_VARS", base.EnvVars),
+		Verbose:               env.getBool("VERBOSE", base.Verbose),
+		DeployParallelism:     env.getInt("DEPLOY_PARALLELISM", base.DeployParallelism),
+		FailFast:              env.getBool("FAIL_FAST", base.FailFast),
+		Hosts:                 base.Hosts,
+	}
+
+	if raw := os.Getenv("SSH_HOST"); raw != "" {
+		hosts, err := ParseHosts(raw)
+		if err != nil {
+			env.problems = append(env.problems, fmt.Sprintf("SSH_HOST is invalid: %v", err))
+		}
+		cfg.Hosts = hosts
+	}
+	if raw := os.Getenv("SSH_JUMP_HOSTS"); raw != "" {
+		jumpHosts, err := ParseJumpHosts(raw)
+		if err != nil {
+			env.problems = append(env.problems, fmt.Sprintf("SSH_JUMP_HOSTS is invalid: %v", err))
+		}
+		cfg.SSHJumpHosts = jumpHosts
+	}
+	if len(cfg.Hosts) == 1 {
+		cfg = cfg.ForHost(cfg.Hosts[0])
+	}
+
+	cfg.normalizeEnums()
+	cfg.parseProblems = env.problems
+
+	return cfg, nil
+}
+
+// normalizeEnums lowercases every setting with a fixed set of values once env
+// and file are merged, so "System" and "system" behave the same whichever
+// source they come from.
+func (c *DeployConfig) normalizeEnums() {
+	for i, method := range c.SSHAuthMethods {
+		c.SSHAuthMethods[i] = strings.ToLower(method)
+	}
+	c.TransferMethod = strings.ToLower(strings.TrimSpace(c.TransferMethod))
+	c.Mode = strings.ToLower(strings.TrimSpace(c.Mode))
+	c.ComposeStrategy = strings.ToLower(strings.TrimSpace(c.ComposeStrategy))
+	c.DockerNetworkDriver = strings.ToLower(strings.TrimSpace(c.DockerNetworkDriver))
+	c.DockerPrune = strings.ToLower(strings.TrimSpace(c.DockerPrune))
+}
+
+func defaultConfig() DeployConfig {
 	return DeployConfig{
-		SSHHost:               getEnv("SSH_HOST", ""),
-		SSHPort:               getEnv("SSH_PORT", "22"),
-		SSHUser:               getEnv("SSH_USER", ""),
-		SSHKey:                getEnv("SSH_KEY", ""),
-		SSHKeyPassphrase:      getEnv("SSH_KEY_PASSPHRASE", ""),
-		SSHKnownHosts:         getEnv("SSH_KNOWN_HOSTS", ""),
-		SSHFingerprint:        getEnv("SSH_FINGERPRINT", ""),
-		SSHTimeout:            getEnv("SSH_TIMEOUT", "10s"),
-		ProjectPath:           getEnv("PROJECT_PATH", ""),
-		DeployFile:            getEnv("DEPLOY_FILE", "docker-compose.yml"),
-		ExtraFiles:            ParseExtraFilesFromEnv("EXTRA_FILES"),
-		Mode:                  getEnv("MODE", "compose"),
-		StackName:             getEnv("STACK_NAME", ""),
-		ComposePull:           getBool("COMPOSE_PULL", true),
-		ComposeBuild:          getBool("COMPOSE_BUILD", false),
-		ComposeNoDeps:         getBool("COMPOSE_NO_DEPS", false),
-		ComposeTargetServices: splitEnv("COMPOSE_TARGET_SERVICES"),
-		DockerNetwork:         getEnv("DOCKER_NETWORK", ""),
-		DockerNetworkDriver:   getEnv("DOCKER_NETWORK_DRIVER", "bridge"),
-		DockerNetworkAttach:   getBool("DOCKER_NETWORK_ATTACHABLE", false),
-		DockerPrune:           getEnv("DOCKER_PRUNE", "none"),
-		RegistryHost:          getEnv("REGISTRY_HOST", ""),
-		RegistryUser:          getEnv("REGISTRY_USER", ""),
-		RegistryPass:          getEnv("REGISTRY_PASS", ""),
-		EnableRollback:        getBool("ENABLE_ROLLBACK", false),
-		EnvVars:               getEnv("ENV_VARS", ""),
-		Verbose:               getBool("VERBOSE", false),
+		SSHPort:               "22",
+		SSHTimeout:            "10s",
+		SSHConnectRetries:     3,
+		SSHConnectRetryDelay:  "2s",
+		SSHKeepaliveInterval:  "15s",
+		SSHKeepaliveMaxMissed: 3,
+		SSHAuthMethods:        []string{"key", "agent"},
+		DeployFile:            "docker-compose.yml",
+		TransferMethod:        "sftp",
+		UploadConcurrency:     4,
+		SkipUnchanged:         true,
+		Mode:                  "compose",
+		ComposePull:           true,
+		ComposeStrategy:       "recreate",
+		ComposeHealthTimeout:  "60s",
+		ComposePullTimeout:    "30m",
+		ComposeUpTimeout:      "30m",
+		StackDeployTimeout:    "30m",
+		ComposeLogTail:        50,
+		StackTaskHistory:      3,
+		DockerNetworkDriver:   "bridge",
+		DockerPrune:           "none",
+		RollbackOnCancel:      true,
+		DeployParallelism:     1,
 	}
 }
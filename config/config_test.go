@@ -9,9 +9,19 @@ import (
 	"testing"
 )
 
+func mustLoadConfig(t *testing.T) DeployConfig {
+	t.Helper()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+	return cfg
+}
+
 func TestLoadConfig_Defaults(t *testing.T) {
 	os.Clearenv()
-	cfg := LoadConfig()
+	cfg := mustLoadConfig(t)
 
 	if cfg.DeployFile != "docker-compose.yml" {
 		t.Errorf("expected DeployFile to be 'docker-compose.yml', got %s", cfg.DeployFile)
@@ -25,6 +35,21 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	if cfg.EnableRollback {
 		t.Errorf("expected EnableRollback to be false, got true")
 	}
+	if !cfg.RollbackOnCancel {
+		t.Errorf("expected RollbackOnCancel to be true, got false")
+	}
+	if cfg.TransferMethod != "sftp" {
+		t.Errorf("expected TransferMethod to default to 'sftp', got %s", cfg.TransferMethod)
+	}
+	if cfg.UploadConcurrency != 4 {
+		t.Errorf("expected UploadConcurrency to default to 4, got %d", cfg.UploadConcurrency)
+	}
+	if !cfg.SkipUnchanged {
+		t.Errorf("expected SkipUnchanged to be true, got false")
+	}
+	if cfg.SyncDelete {
+		t.Errorf("expected SyncDelete to be false, got true")
+	}
 	if cfg.SSHTimeout != "10s" {
 		t.Errorf("expected SSHTimeout to default to '10s', got %s", cfg.SSHTimeout)
 	}
@@ -34,6 +59,44 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	if len(cfg.ComposeTargetServices) != 0 {
 		t.Errorf("expected ComposeTargetServices to be empty, got %v", cfg.ComposeTargetServices)
 	}
+	if cfg.ComposeStrategy != "recreate" {
+		t.Errorf("expected ComposeStrategy to default to 'recreate', got %s", cfg.ComposeStrategy)
+	}
+	if cfg.ComposeHealthTimeout != "60s" {
+		t.Errorf("expected ComposeHealthTimeout to default to '60s', got %s", cfg.ComposeHealthTimeout)
+	}
+	if cfg.ComposePullTimeout != "30m" || cfg.ComposeUpTimeout != "30m" || cfg.StackDeployTimeout != "30m" {
+		t.Errorf("expected phase timeouts to default to 30m, got pull=%s up=%s stack=%s", cfg.ComposePullTimeout, cfg.ComposeUpTimeout, cfg.StackDeployTimeout)
+	}
+	if cfg.ComposeLogTail != 50 {
+		t.Errorf("expected ComposeLogTail to default to 50, got %d", cfg.ComposeLogTail)
+	}
+	if cfg.StackTaskHistory != 3 {
+		t.Errorf("expected StackTaskHistory to default to 3, got %d", cfg.StackTaskHistory)
+	}
+	if cfg.SSHConnectRetries != 3 || cfg.SSHConnectRetryDelay != "2s" {
+		t.Errorf("expected connect retries to default to 3 every 2s, got %d every %s", cfg.SSHConnectRetries, cfg.SSHConnectRetryDelay)
+	}
+	if cfg.SSHKeepaliveInterval != "15s" || cfg.SSHKeepaliveMaxMissed != 3 {
+		t.Errorf("expected keepalives to default to 3 missed at 15s, got %d at %s", cfg.SSHKeepaliveMaxMissed, cfg.SSHKeepaliveInterval)
+	}
+	if !reflect.DeepEqual(cfg.SSHAuthMethods, []string{"key", "agent"}) {
+		t.Errorf("expected SSHAuthMethods to default to [key agent], got %v", cfg.SSHAuthMethods)
+	}
+}
+
+func TestLoadConfig_AuthMethods(t *testing.T) {
+	t.Setenv("SSH_AUTH_METHODS", "Agent, key")
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+
+	cfg := mustLoadConfig(t)
+
+	if !reflect.DeepEqual(cfg.SSHAuthMethods, []string{"agent", "key"}) {
+		t.Errorf("expected SSHAuthMethods to be [agent key], got %v", cfg.SSHAuthMethods)
+	}
+	if !cfg.UsesSSHAgent() {
+		t.Error("expected UsesSSHAgent to be true when a socket is set")
+	}
 }
 
 func TestLoadConfig_WithEnvOverrides(t *testing.T) {
@@ -41,9 +104,15 @@ func TestLoadConfig_WithEnvOverrides(t *testing.T) {
 	t.Setenv("MODE", "stack")
 	t.Setenv("DOCKER_NETWORK_ATTACHABLE", "true")
 	t.Setenv("ENABLE_ROLLBACK", "true")
+	t.Setenv("ROLLBACK_ON_CANCEL", "false")
+	t.Setenv("SKIP_UNCHANGED", "false")
+	t.Setenv("SYNC_DELETE", "true")
 	t.Setenv("SSH_TIMEOUT", "20s")
+	t.Setenv("COMPOSE_STRATEGY", "in-place")
+	t.Setenv("COMPOSE_LOG_TAIL", "200")
+	t.Setenv("STACK_TASK_HISTORY", "5")
 
-	cfg := LoadConfig()
+	cfg := mustLoadConfig(t)
 
 	if cfg.DeployFile != "override.yml" {
 		t.Errorf("expected DeployFile to be 'override.yml', got %s", cfg.DeployFile)
@@ -57,9 +126,27 @@ func TestLoadConfig_WithEnvOverrides(t *testing.T) {
 	if !cfg.EnableRollback {
 		t.Errorf("expected EnableRollback to be true, got false")
 	}
+	if cfg.RollbackOnCancel {
+		t.Errorf("expected RollbackOnCancel to be false, got true")
+	}
+	if cfg.SkipUnchanged {
+		t.Errorf("expected SkipUnchanged to be false, got true")
+	}
+	if !cfg.SyncDelete {
+		t.Errorf("expected SyncDelete to be true, got false")
+	}
 	if cfg.SSHTimeout != "20s" {
 		t.Errorf("expected SSHTimeout to be '20s', got %s", cfg.SSHTimeout)
 	}
+	if cfg.ComposeStrategy != "in-place" {
+		t.Errorf("expected ComposeStrategy to be 'in-place', got %s", cfg.ComposeStrategy)
+	}
+	if cfg.ComposeLogTail != 200 {
+		t.Errorf("expected ComposeLogTail to be 200, got %d", cfg.ComposeLogTail)
+	}
+	if cfg.StackTaskHistory != 5 {
+		t.Errorf("expected StackTaskHistory to be 5, got %d", cfg.StackTaskHistory)
+	}
 }
 
 func TestLoadConfig_SliceParsing_Newline(t *testing.T) {
@@ -72,7 +159,7 @@ func TestLoadConfig_SliceParsing_Newline(t *testing.T) {
 		db
 	`)
 
-	cfg := LoadConfig()
+	cfg := mustLoadConfig(t)
 
 	expectedExtra := []ExtraFile{
 		{Src: "./tests/testdata/stack/redis.conf", Dst: "", Flatten: true},
@@ -93,7 +180,7 @@ func TestLoadConfig_EmptySlices(t *testing.T) {
 	t.Setenv("EXTRA_FILES", "")
 	t.Setenv("COMPOSE_TARGET_SERVICES", "")
 
-	cfg := LoadConfig()
+	cfg := mustLoadConfig(t)
 
 	if len(cfg.ExtraFiles) != 0 {
 		t.Errorf("expected ExtraFiles to be empty, got %v", cfg.ExtraFiles)
@@ -105,18 +192,27 @@ func TestLoadConfig_EmptySlices(t *testing.T) {
 
 func TestLoadConfig_BoolParsing(t *testing.T) {
 	t.Setenv("COMPOSE_BUILD", "true")
-	if !LoadConfig().ComposeBuild {
+	if !mustLoadConfig(t).ComposeBuild {
 		t.Error("expected ComposeBuild to be true for 'true'")
 	}
 
 	t.Setenv("COMPOSE_BUILD", "false")
-	if LoadConfig().ComposeBuild {
+	if mustLoadConfig(t).ComposeBuild {
 		t.Error("expected ComposeBuild to be false for 'false'")
 	}
 
-	t.Setenv("COMPOSE_BUILD", "yes")
-	if LoadConfig().ComposeBuild {
-		t.Error("expected ComposeBuild to be false for non-'true' input")
+	for _, val := range []string{"yes", "1", "TRUE"} {
+		t.Setenv("COMPOSE_BUILD", val)
+		if !mustLoadConfig(t).ComposeBuild {
+			t.Errorf("expected ComposeBuild to be true for '%s'", val)
+		}
+	}
+
+	for _, val := range []string{"no", "0", "False"} {
+		t.Setenv("COMPOSE_BUILD", val)
+		if mustLoadConfig(t).ComposeBuild {
+			t.Errorf("expected ComposeBuild to be false for '%s'", val)
+		}
 	}
 }
 
@@ -148,7 +244,7 @@ func TestLoadConfig_AllFieldsSet(t *testing.T) {
 	t.Setenv("EXTRA_FILES", "file1.env\nfile2.env")
 	t.Setenv("COMPOSE_TARGET_SERVICES", "web\nworker")
 
-	cfg := LoadConfig()
+	cfg := mustLoadConfig(t)
 
 	if cfg.SSHHost != "example.com" || cfg.SSHUser != "deployer" || cfg.ProjectPath != "/app" {
 		t.Errorf("unexpected SSH or project config values: %+v", cfg)
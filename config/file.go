@@ -0,0 +1,294 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func LoadConfigFile(path string) (DeployConfig, error) {
+	cfg := defaultConfig()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var fc fileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fc); err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("failed to parse config file '%s':\n   \u2192 %s", path, err)
+	}
+
+	fc.applyTo(&cfg)
+
+	if len(fc.Hosts) == 0 && fc.SSHHost != "" {
+		hosts, err := ParseHosts(fc.SSHHost)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid ssh_host in config file '%s': %w", path, err)
+		}
+		cfg.Hosts = hosts
+	}
+
+	return cfg, nil
+}
+
+func (fc fileConfig) applyTo(cfg *DeployConfig) {
+	setString(&cfg.SSHHost, fc.SSHHost)
+	setString(&cfg.SSHPort, fc.SSHPort)
+	setString(&cfg.SSHUser, fc.SSHUser)
+	setString(&cfg.SSHKey, fc.SSHKey)
+	setString(&cfg.SSHKeyPassphrase, fc.SSHKeyPassphrase)
+	setString(&cfg.SSHJumpKey, fc.SSHJumpKey)
+	setString(&cfg.SSHJumpKeyPassphrase, fc.SSHJumpKeyPassphrase)
+	setString(&cfg.SSHJumpCertificate, fc.SSHJumpCertificate)
+	setString(&cfg.SSHJumpKnownHosts, fc.SSHJumpKnownHosts)
+	setString(&cfg.SSHJumpFingerprint, fc.SSHJumpFingerprint)
+	setString(&cfg.SSHCertificate, fc.SSHCertificate)
+	setString(&cfg.SSHAgentSocket, fc.SSHAgentSocket)
+	setString(&cfg.SSHKnownHosts, fc.SSHKnownHosts)
+	setString(&cfg.SSHFingerprint, string(fc.SSHFingerprint))
+	setString(&cfg.SSHHostCA, fc.SSHHostCA)
+	setString(&cfg.SSHRevokedKeys, fc.SSHRevokedKeys)
+	setString(&cfg.SSHTimeout, fc.SSHTimeout)
+	setString(&cfg.SSHConnectRetryDelay, fc.SSHConnectRetryDelay)
+	setString(&cfg.SSHKeepaliveInterval, fc.SSHKeepaliveInterval)
+	setString(&cfg.ProjectPath, fc.ProjectPath)
+	setString(&cfg.DeployFile, fc.DeployFile)
+	setString(&cfg.TransferMethod, fc.TransferMethod)
+	setString(&cfg.Mode, fc.Mode)
+	setString(&cfg.StackName, fc.StackName)
+	setString(&cfg.StackDeployTimeout, fc.StackDeployTimeout)
+	setString(&cfg.ComposeStrategy, fc.ComposeStrategy)
+	setString(&cfg.ComposeHealthTimeout, fc.ComposeHealthTimeout)
+	setString(&cfg.ComposePullTimeout, fc.ComposePullTimeout)
+	setString(&cfg.ComposeUpTimeout, fc.ComposeUpTimeout)
+	setString(&cfg.DockerNetwork, fc.DockerNetwork)
+	setString(&cfg.DockerNetworkDriver, fc.DockerNetworkDriver)
+	setString(&cfg.DockerPrune, fc.DockerPrune)
+	setString(&cfg.RegistryHost, fc.RegistryHost)
+	setString(&cfg.RegistryUser, fc.RegistryUser)
+	setString(&cfg.RegistryPass, fc.RegistryPass)
+	setString(&cfg.EnvVars, string(fc.EnvVars))
+
+	setBool(&cfg.SkipUnchanged, fc.SkipUnchanged)
+	setBool(&cfg.SyncDelete, fc.SyncDelete)
+	setBool(&cfg.ComposePull, fc.ComposePull)
+	setBool(&cfg.ComposeBuild, fc.ComposeBuild)
+	setBool(&cfg.ComposeNoDeps, fc.ComposeNoDeps)
+	setBool(&cfg.DockerNetworkAttach, fc.DockerNetworkAttach)
+	setBool(&cfg.EnableRollback, fc.EnableRollback)
+	setBool(&cfg.RollbackOnCancel, fc.RollbackOnCancel)
+	setBool(&cfg.Verbose, fc.Verbose)
+
+	setBool(&cfg.FailFast, fc.FailFast)
+
+	if fc.SSHConnectRetries != nil {
+		cfg.SSHConnectRetries = *fc.SSHConnectRetries
+	}
+	if fc.SSHKeepaliveMaxMissed != nil {
+		cfg.SSHKeepaliveMaxMissed = *fc.SSHKeepaliveMaxMissed
+	}
+	if fc.UploadConcurrency != nil {
+		cfg.UploadConcurrency = *fc.UploadConcurrency
+	}
+	if fc.ComposeLogTail != nil {
+		cfg.ComposeLogTail = *fc.ComposeLogTail
+	}
+	if fc.StackTaskHistory != nil {
+		cfg.StackTaskHistory = *fc.StackTaskHistory
+	}
+	if fc.DeployParallelism != nil {
+		cfg.DeployParallelism = *fc.DeployParallelism
+	}
+	if len(fc.Hosts) > 0 {
+		cfg.Hosts = fc.Hosts
+	}
+	if len(fc.SSHAuthMethods) > 0 {
+		cfg.SSHAuthMethods = fc.SSHAuthMethods
+	}
+	if len(fc.SSHJumpHosts) > 0 {
+		cfg.SSHJumpHosts = fc.SSHJumpHosts
+	}
+	if len(fc.ExtraFiles) > 0 {
+		cfg.ExtraFiles = fc.ExtraFiles
+	}
+	if len(fc.ComposeTargetServices) > 0 {
+		cfg.ComposeTargetServices = fc.ComposeTargetServices
+	}
+}
+
+func setString(dst *string, val string) {
+	if val != "" {
+		*dst = val
+	}
+}
+
+func setBool(dst *bool, val *bool) {
+	if val != nil {
+		*dst = *val
+	}
+}
+
+func (ef *ExtraFile) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var line string
+		if err := value.Decode(&line); err != nil {
+			return fmt.Errorf("invalid extra file: %w", err)
+		}
+		*ef = parseExtraFile(strings.TrimSpace(line))
+
+	case yaml.MappingNode:
+		var entry struct {
+			Src     string `yaml:"src"`
+			Dst     string `yaml:"dst"`
+			Flatten bool   `yaml:"flatten"`
+		}
+		if err := value.Decode(&entry); err != nil {
+			return fmt.Errorf("invalid extra file: %w", err)
+		}
+		if entry.Src == "" {
+			return fmt.Errorf("line %d: extra file is missing 'src'", value.Line)
+		}
+		*ef = ExtraFile{Src: entry.Src, Dst: entry.Dst, Flatten: entry.Flatten}
+
+	default:
+		return fmt.Errorf("line %d: extra file must be a string or a map with 'src', 'dst' and 'flatten'", value.Line)
+	}
+	return nil
+}
+
+func (h *HostConfig) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var spec string
+		if err := value.Decode(&spec); err != nil {
+			return fmt.Errorf("invalid host: %w", err)
+		}
+		parsed, err := parseHostSpec(spec)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*h = parsed
+
+	case yaml.MappingNode:
+		type plain HostConfig
+		var entry plain
+		if err := value.Decode(&entry); err != nil {
+			return fmt.Errorf("invalid host: %w", err)
+		}
+		if entry.Host == "" {
+			return fmt.Errorf("line %d: host entry is missing 'host'", value.Line)
+		}
+		*h = HostConfig(entry)
+
+	default:
+		return fmt.Errorf("line %d: host must be a string or a map with 'host', 'port', 'user' and 'project_path'", value.Line)
+	}
+	return nil
+}
+
+func (j *JumpHost) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var spec string
+		if err := value.Decode(&spec); err != nil {
+			return fmt.Errorf("invalid jump host: %w", err)
+		}
+		parsed, err := parseHostSpec(spec)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*j = JumpHost{Host: parsed.Host, Port: parsed.Port, User: parsed.User}
+
+	case yaml.MappingNode:
+		type plain JumpHost
+		var entry plain
+		if err := value.Decode(&entry); err != nil {
+			return fmt.Errorf("invalid jump host: %w", err)
+		}
+		if entry.Host == "" {
+			return fmt.Errorf("line %d: jump host entry is missing 'host'", value.Line)
+		}
+		*j = JumpHost(entry)
+
+	default:
+		return fmt.Errorf("line %d: jump host must be a string or a map with 'host', 'port', 'user', 'key', 'certificate', 'known_hosts' and 'fingerprint'", value.Line)
+	}
+	return nil
+}
+
+func (fl *fingerprintList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var fingerprint string
+		if err := value.Decode(&fingerprint); err != nil {
+			return fmt.Errorf("invalid ssh_fingerprint: %w", err)
+		}
+		*fl = fingerprintList(fingerprint)
+
+	case yaml.SequenceNode:
+		var fingerprints []string
+		if err := value.Decode(&fingerprints); err != nil {
+			return fmt.Errorf("invalid ssh_fingerprint: %w", err)
+		}
+		*fl = fingerprintList(strings.Join(fingerprints, "\n"))
+
+	default:
+		return fmt.Errorf("line %d: ssh_fingerprint must be a string or a list of strings", value.Line)
+	}
+	return nil
+}
+
+func (ev *envVarList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var block string
+		if err := value.Decode(&block); err != nil {
+			return fmt.Errorf("invalid env_vars: %w", err)
+		}
+		*ev = envVarList(block)
+
+	case yaml.SequenceNode:
+		var lines []string
+		for _, item := range value.Content {
+			var pair string
+			if err := item.Decode(&pair); err != nil {
+				return fmt.Errorf("invalid env_vars item: %w", err)
+			}
+			if !strings.Contains(pair, "=") {
+				return fmt.Errorf("line %d: invalid env_vars item (expected KEY=VALUE): %s", item.Line, pair)
+			}
+			lines = append(lines, pair)
+		}
+		*ev = envVarList(strings.Join(lines, "\n"))
+
+	case yaml.MappingNode:
+		var lines []string
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			var key, val string
+			if err := value.Content[i].Decode(&key); err != nil {
+				return fmt.Errorf("invalid env_vars key: %w", err)
+			}
+			if err := value.Content[i+1].Decode(&val); err != nil {
+				return fmt.Errorf("invalid env_vars value for '%s': %w", key, err)
+			}
+			lines = append(lines, key+"="+val)
+		}
+		*ev = envVarList(strings.Join(lines, "\n"))
+
+	default:
+		return fmt.Errorf("line %d: env_vars must be a string, a list of KEY=VALUE strings or a map", value.Line)
+	}
+	return nil
+}
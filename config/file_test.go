@@ -0,0 +1,280 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "deploy.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig_FromFile(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+ssh_host: example.com
+ssh_port: 2222
+ssh_user: deployer
+project_path: /opt/app
+mode: stack
+stack_name: app
+compose_pull: false
+compose_log_tail: 20
+stack_task_history: 1
+extra_files:
+  - flatten configs/nginx.conf
+  - src: assets/
+    dst: public/
+  - src: configs/*.env
+    flatten: true
+compose_target_services:
+  - web
+  - worker
+env_vars:
+  DB_HOST: localhost
+  DB_PORT: "5432"
+`))
+
+	cfg := mustLoadConfig(t)
+
+	if cfg.SSHHost != "example.com" || cfg.SSHPort != "2222" || cfg.SSHUser != "deployer" {
+		t.Errorf("unexpected SSH values from file: %+v", cfg)
+	}
+	if cfg.ProjectPath != "/opt/app" || cfg.Mode != "stack" || cfg.StackName != "app" {
+		t.Errorf("unexpected deploy values from file: %+v", cfg)
+	}
+	if cfg.ComposePull {
+		t.Error("expected ComposePull to be false from file")
+	}
+	if cfg.ComposeLogTail != 20 {
+		t.Errorf("expected ComposeLogTail to be 20, got %d", cfg.ComposeLogTail)
+	}
+	if cfg.StackTaskHistory != 1 {
+		t.Errorf("expected StackTaskHistory to be 1, got %d", cfg.StackTaskHistory)
+	}
+	if cfg.DeployFile != "docker-compose.yml" || cfg.SSHTimeout != "10s" {
+		t.Errorf("expected defaults for values missing from file, got %+v", cfg)
+	}
+
+	expectedExtra := []ExtraFile{
+		{Src: "configs/nginx.conf", Flatten: true},
+		{Src: "assets/", Dst: "public/"},
+		{Src: "configs/*.env", Flatten: true},
+	}
+	if !reflect.DeepEqual(cfg.ExtraFiles, expectedExtra) {
+		t.Errorf("expected ExtraFiles to be %v, got %v", expectedExtra, cfg.ExtraFiles)
+	}
+
+	if !reflect.DeepEqual(cfg.ComposeTargetServices, []string{"web", "worker"}) {
+		t.Errorf("unexpected ComposeTargetServices: %v", cfg.ComposeTargetServices)
+	}
+	if cfg.EnvVars != "DB_HOST=localhost\nDB_PORT=5432" {
+		t.Errorf("unexpected EnvVars: %q", cfg.EnvVars)
+	}
+}
+
+func TestLoadConfig_EnvOverridesFile(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+ssh_host: file.example.com
+mode: stack
+compose_build: true
+compose_target_services: [web]
+env_vars:
+  - FOO=file
+`))
+	t.Setenv("SSH_HOST", "env.example.com")
+	t.Setenv("COMPOSE_BUILD", "false")
+	t.Setenv("COMPOSE_TARGET_SERVICES", "api")
+	t.Setenv("ENV_VARS", "FOO=env")
+
+	cfg := mustLoadConfig(t)
+
+	if cfg.SSHHost != "env.example.com" {
+		t.Errorf("expected SSH_HOST env to override file, got %s", cfg.SSHHost)
+	}
+	if cfg.Mode != "stack" {
+		t.Errorf("expected Mode from file, got %s", cfg.Mode)
+	}
+	if cfg.ComposeBuild {
+		t.Error("expected COMPOSE_BUILD env to override file")
+	}
+	if !reflect.DeepEqual(cfg.ComposeTargetServices, []string{"api"}) {
+		t.Errorf("expected COMPOSE_TARGET_SERVICES env to override file, got %v", cfg.ComposeTargetServices)
+	}
+	if cfg.EnvVars != "FOO=env" {
+		t.Errorf("expected ENV_VARS env to override file, got %q", cfg.EnvVars)
+	}
+}
+
+func TestLoadConfig_EnumCase(t *testing.T) {
+	check := func(t *testing.T, cfg DeployConfig) {
+		t.Helper()
+		if !reflect.DeepEqual(cfg.SSHAuthMethods, []string{"agent", "key"}) {
+			t.Errorf("expected SSHAuthMethods to be [agent key], got %v", cfg.SSHAuthMethods)
+		}
+		if cfg.Mode != "stack" || cfg.TransferMethod != "scp" || cfg.ComposeStrategy != "in-place" {
+			t.Errorf("expected lowercased mode, transfer method and strategy, got %s, %s, %s", cfg.Mode, cfg.TransferMethod, cfg.ComposeStrategy)
+		}
+		if cfg.DockerPrune != "system" || cfg.DockerNetworkDriver != "overlay" {
+			t.Errorf("expected lowercased prune and network driver, got %s, %s", cfg.DockerPrune, cfg.DockerNetworkDriver)
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	t.Run("env", func(t *testing.T) {
+		os.Clearenv()
+		t.Setenv("SSH_HOST", "example.com")
+		t.Setenv("SSH_USER", "deployer")
+		t.Setenv("SSH_KEY", "key")
+		t.Setenv("PROJECT_PATH", "/opt/app")
+		t.Setenv("STACK_NAME", "app")
+		t.Setenv("SSH_AUTH_METHODS", "Agent, KEY")
+		t.Setenv("MODE", "Stack")
+		t.Setenv("TRANSFER_METHOD", "SCP")
+		t.Setenv("COMPOSE_STRATEGY", "In-Place")
+		t.Setenv("DOCKER_PRUNE", "System")
+		t.Setenv("DOCKER_NETWORK", "net")
+		t.Setenv("DOCKER_NETWORK_DRIVER", "Overlay")
+
+		check(t, mustLoadConfig(t))
+	})
+
+	t.Run("file", func(t *testing.T) {
+		os.Clearenv()
+		t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+ssh_host: example.com
+ssh_user: deployer
+ssh_key: key
+project_path: /opt/app
+stack_name: app
+ssh_auth_methods: [Agent, KEY]
+mode: Stack
+transfer_method: SCP
+compose_strategy: In-Place
+docker_prune: System
+docker_network: net
+docker_network_driver: Overlay
+`))
+
+		check(t, mustLoadConfig(t))
+	})
+}
+
+func TestLoadConfig_FileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"unknown key", "ssh_hots: example.com\n", "ssh_hots"},
+		{"extra file without src", "extra_files:\n  - dst: configs/\n", "missing 'src'"},
+		{"invalid env var item", "env_vars:\n  - FOO\n", "expected KEY=VALUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			t.Setenv("CONFIG_FILE", writeConfigFile(t, tt.content))
+
+			_, err := LoadConfig()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_MissingFile(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yml"))
+
+	if _, err := LoadConfig(); err == nil {
+		t.Error("expected error for missing config file")
+	}
+}
+
+func TestLoadConfig_HostsFromFile(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+ssh_user: deployer
+project_path: /opt/app
+hosts:
+  - edge-1.example.com
+  - host: edge-2.example.com
+    port: 2222
+    user: admin
+    project_path: /srv/app
+`))
+
+	cfg := mustLoadConfig(t)
+
+	expected := []HostConfig{
+		{Host: "edge-1.example.com"},
+		{Host: "edge-2.example.com", Port: "2222", User: "admin", ProjectPath: "/srv/app"},
+	}
+	if !reflect.DeepEqual(cfg.Hosts, expected) {
+		t.Errorf("expected Hosts to be %+v, got %+v", expected, cfg.Hosts)
+	}
+
+	second := cfg.ForHost(cfg.Hosts[1])
+	if second.SSHHost != "edge-2.example.com" || second.SSHPort != "2222" || second.SSHUser != "admin" || second.ProjectPath != "/srv/app" {
+		t.Errorf("unexpected per-host config: %+v", second)
+	}
+
+	first := cfg.ForHost(cfg.Hosts[0])
+	if first.SSHPort != "22" || first.SSHUser != "deployer" || first.ProjectPath != "/opt/app" {
+		t.Errorf("expected first host to inherit shared values, got %+v", first)
+	}
+}
+
+func TestLoadConfig_JumpHostsFromFile(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+ssh_jump_hosts:
+  - ops@bastion-1.example.com:2222
+  - host: bastion-2.internal
+    key: bastion-key
+    fingerprint: SHA256:abc
+`))
+
+	cfg := mustLoadConfig(t)
+
+	expected := []JumpHost{
+		{Host: "bastion-1.example.com", Port: "2222", User: "ops"},
+		{Host: "bastion-2.internal", Key: "bastion-key", Fingerprint: "SHA256:abc"},
+	}
+	if !reflect.DeepEqual(cfg.SSHJumpHosts, expected) {
+		t.Errorf("expected SSHJumpHosts to be %+v, got %+v", expected, cfg.SSHJumpHosts)
+	}
+}
+
+func TestLoadConfig_FingerprintListFromFile(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+ssh_fingerprint:
+  - SHA256:old
+  - MD5:16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48
+`))
+
+	cfg := mustLoadConfig(t)
+
+	expected := "SHA256:old\nMD5:16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48"
+	if cfg.SSHFingerprint != expected {
+		t.Errorf("expected SSHFingerprint to be %q, got %q", expected, cfg.SSHFingerprint)
+	}
+}
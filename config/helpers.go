@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -12,18 +14,51 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
-func getBool(key string, fallback bool) bool {
+func (e *envReader) getBool(key string, fallback bool) bool {
 	val := os.Getenv(key)
 	if val == "" {
 		return fallback
 	}
-	return val == "true"
+
+	parsed, ok := parseBool(val)
+	if !ok {
+		e.problems = append(e.problems, fmt.Sprintf("%s has invalid boolean value '%s' (use true/false, yes/no or 1/0)", key, val))
+		return fallback
+	}
+	return parsed
+}
+
+func (e *envReader) getInt(key string, fallback int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil {
+		e.problems = append(e.problems, fmt.Sprintf("%s has invalid number '%s'", key, val))
+		return fallback
+	}
+	return n
+}
+
+func parseBool(val string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "true", "yes", "1":
+		return true, true
+	case "false", "no", "0":
+		return false, true
+	}
+	return false, false
 }
 
-func splitEnv(key string) []string {
+func splitEnv(key string, fallback []string) []string {
 	val := os.Getenv(key)
 	if val == "" {
-		return []string{}
+		if fallback == nil {
+			return []string{}
+		}
+		return fallback
 	}
 
 	lines := strings.Split(val, "\n")
@@ -37,6 +72,18 @@ func splitEnv(key string) []string {
 	return cleaned
 }
 
+func splitCommas(values []string) []string {
+	split := []string{}
+	for _, val := range values {
+		for part := range strings.SplitSeq(val, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
+
 func ParseExtraFilesFromEnv(key string) []ExtraFile {
 	val := os.Getenv(key)
 	if val == "" {
@@ -51,28 +98,32 @@ func ParseExtraFilesFromEnv(key string) []ExtraFile {
 			continue
 		}
 
-		flatten := false
-		if strings.HasPrefix(line, "flatten ") {
-			flatten = true
-			line = strings.TrimPrefix(line, "flatten ")
-		}
+		files = append(files, parseExtraFile(line))
+	}
+	return files
+}
 
-		parts := strings.SplitN(line, ":", 2)
-		src := ""
-		dst := ""
+func parseExtraFile(line string) ExtraFile {
+	flatten := false
+	if strings.HasPrefix(line, "flatten ") {
+		flatten = true
+		line = strings.TrimPrefix(line, "flatten ")
+	}
 
-		if len(parts) == 2 {
-			src = strings.TrimSpace(parts[0])
-			dst = strings.TrimSpace(parts[1])
-		} else {
-			src = strings.TrimSpace(line)
-		}
+	parts := strings.SplitN(line, ":", 2)
+	src := ""
+	dst := ""
 
-		files = append(files, ExtraFile{
-			Src:     src,
-			Dst:     dst,
-			Flatten: flatten,
-		})
+	if len(parts) == 2 {
+		src = strings.TrimSpace(parts[0])
+		dst = strings.TrimSpace(parts[1])
+	} else {
+		src = strings.TrimSpace(line)
+	}
+
+	return ExtraFile{
+		Src:     src,
+		Dst:     dst,
+		Flatten: flatten,
 	}
-	return files
 }
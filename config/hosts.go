@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+func ParseHosts(raw string) ([]HostConfig, error) {
+	var hosts []HostConfig
+
+	for _, spec := range strings.FieldsFunc(raw, func(r rune) bool { return r == '\n' || r == ',' }) {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		host, err := parseHostSpec(spec)
+		if err != nil {
+			return hosts, err
+		}
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
+
+func ParseJumpHosts(raw string) ([]JumpHost, error) {
+	hosts, err := ParseHosts(raw)
+
+	jumpHosts := make([]JumpHost, 0, len(hosts))
+	for _, h := range hosts {
+		jumpHosts = append(jumpHosts, JumpHost{Host: h.Host, Port: h.Port, User: h.User})
+	}
+	return jumpHosts, err
+}
+
+func parseHostSpec(spec string) (HostConfig, error) {
+	var h HostConfig
+	spec = strings.TrimSpace(spec)
+
+	if at := strings.LastIndex(spec, "@"); at >= 0 {
+		h.User = spec[:at]
+		spec = spec[at+1:]
+	}
+
+	switch {
+	case strings.HasPrefix(spec, "["), strings.Count(spec, ":") == 1:
+		host, port, err := net.SplitHostPort(spec)
+		if err != nil {
+			return h, fmt.Errorf("invalid host '%s': %w", spec, err)
+		}
+		if port == "" {
+			return h, fmt.Errorf("invalid host '%s': port is empty", spec)
+		}
+		h.Host = host
+		h.Port = port
+	default:
+		h.Host = spec
+	}
+
+	if h.Host == "" {
+		return h, fmt.Errorf("invalid host '%s': hostname is empty", spec)
+	}
+
+	return h, nil
+}
+
+func (c DeployConfig) ForHost(h HostConfig) DeployConfig {
+	hostCfg := c
+	hostCfg.SSHHost = h.Host
+	if h.Port != "" {
+		hostCfg.SSHPort = h.Port
+	}
+	if h.User != "" {
+		hostCfg.SSHUser = h.User
+	}
+	if h.ProjectPath != "" {
+		hostCfg.ProjectPath = h.ProjectPath
+	}
+	hostCfg.Hosts = []HostConfig{h}
+	return hostCfg
+}
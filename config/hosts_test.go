@@ -0,0 +1,80 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHosts(t *testing.T) {
+	hosts, err := ParseHosts(`
+		web-1.example.com
+		deployer@web-2.example.com:2222, 10.0.0.5
+		[2001:db8::1]:2200
+		2001:db8::2
+	`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []HostConfig{
+		{Host: "web-1.example.com"},
+		{Host: "web-2.example.com", Port: "2222", User: "deployer"},
+		{Host: "10.0.0.5"},
+		{Host: "2001:db8::1", Port: "2200"},
+		{Host: "2001:db8::2"},
+	}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("expected %+v, got %+v", expected, hosts)
+	}
+}
+
+func TestParseHosts_Invalid(t *testing.T) {
+	for _, raw := range []string{"deployer@", "host:"} {
+		if _, err := ParseHosts(raw); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestLoadConfig_SingleHostSpec(t *testing.T) {
+	t.Setenv("SSH_HOST", "admin@example.com:2200")
+	t.Setenv("SSH_USER", "deployer")
+
+	cfg := mustLoadConfig(t)
+
+	if cfg.SSHHost != "example.com" || cfg.SSHPort != "2200" || cfg.SSHUser != "admin" {
+		t.Errorf("expected host spec to set host, port and user, got %+v", cfg)
+	}
+}
+
+func TestLoadConfig_JumpHostsFromEnv(t *testing.T) {
+	t.Setenv("SSH_JUMP_HOSTS", "ops@bastion.example.com:2222\n10.0.0.1")
+
+	cfg := mustLoadConfig(t)
+
+	expected := []JumpHost{
+		{Host: "bastion.example.com", Port: "2222", User: "ops"},
+		{Host: "10.0.0.1"},
+	}
+	if !reflect.DeepEqual(cfg.SSHJumpHosts, expected) {
+		t.Errorf("expected SSHJumpHosts to be %+v, got %+v", expected, cfg.SSHJumpHosts)
+	}
+}
+
+func TestLoadConfig_JumpCredentialsFromEnv(t *testing.T) {
+	t.Setenv("SSH_JUMP_HOSTS", "ops@bastion.example.com")
+	t.Setenv("SSH_JUMP_KEY", "jump-key")
+	t.Setenv("SSH_JUMP_FINGERPRINT", "SHA256:abc")
+
+	cfg := mustLoadConfig(t)
+
+	if cfg.SSHJumpKey != "jump-key" {
+		t.Errorf("expected SSHJumpKey to be 'jump-key', got %s", cfg.SSHJumpKey)
+	}
+	if cfg.SSHJumpFingerprint != "SHA256:abc" {
+		t.Errorf("expected SSHJumpFingerprint to be 'SHA256:abc', got %s", cfg.SSHJumpFingerprint)
+	}
+}
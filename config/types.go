@@ -1,23 +1,50 @@
 package config
 
 type DeployConfig struct {
+	Hosts                 []HostConfig
 	SSHHost               string
 	SSHPort               string
 	SSHUser               string
 	SSHKey                string
 	SSHKeyPassphrase      string
+	SSHCertificate        string
+	SSHAgentSocket        string
+	SSHAuthMethods        []string
 	SSHKnownHosts         string
 	SSHFingerprint        string
+	SSHHostCA             string
+	SSHRevokedKeys        string
 	SSHTimeout            string
+	SSHConnectRetries     int
+	SSHConnectRetryDelay  string
+	SSHKeepaliveInterval  string
+	SSHKeepaliveMaxMissed int
+	SSHJumpHosts          []JumpHost
+	SSHJumpKey            string
+	SSHJumpKeyPassphrase  string
+	SSHJumpCertificate    string
+	SSHJumpKnownHosts     string
+	SSHJumpFingerprint    string
 	ProjectPath           string
 	DeployFile            string
 	ExtraFiles            []ExtraFile
+	TransferMethod        string
+	UploadConcurrency     int
+	SkipUnchanged         bool
+	SyncDelete            bool
 	Mode                  string
 	StackName             string
+	StackDeployTimeout    string
 	ComposePull           bool
 	ComposeBuild          bool
 	ComposeNoDeps         bool
 	ComposeTargetServices []string
+	ComposeStrategy       string
+	ComposeHealthTimeout  string
+	ComposePullTimeout    string
+	ComposeUpTimeout      string
+	ComposeLogTail        int
+	StackTaskHistory      int
 	DockerNetwork         string
 	DockerNetworkDriver   string
 	DockerNetworkAttach   bool
@@ -26,10 +53,36 @@ type DeployConfig struct {
 	RegistryUser          string
 	RegistryPass          string
 	EnableRollback        bool
+	RollbackOnCancel      bool
 	EnvVars               string
 	Verbose               bool
+	DeployParallelism     int
+	FailFast              bool
 	RollbackTriggered     bool
 	ComposeBinary         string
+	parseProblems         []string
+}
+
+type HostConfig struct {
+	Host        string `yaml:"host"`
+	Port        string `yaml:"port"`
+	User        string `yaml:"user"`
+	ProjectPath string `yaml:"project_path"`
+}
+
+type JumpHost struct {
+	Host          string `yaml:"host"`
+	Port          string `yaml:"port"`
+	User          string `yaml:"user"`
+	Key           string `yaml:"key"`
+	KeyPassphrase string `yaml:"key_passphrase"`
+	Certificate   string `yaml:"certificate"`
+	KnownHosts    string `yaml:"known_hosts"`
+	Fingerprint   string `yaml:"fingerprint"`
+}
+
+type envReader struct {
+	problems []string
 }
 
 type ExtraFile struct {
@@ -37,3 +90,67 @@ type ExtraFile struct {
 	Dst     string
 	Flatten bool
 }
+
+type fileConfig struct {
+	Hosts                 []HostConfig    `yaml:"hosts"`
+	SSHHost               string          `yaml:"ssh_host"`
+	SSHPort               string          `yaml:"ssh_port"`
+	SSHUser               string          `yaml:"ssh_user"`
+	SSHKey                string          `yaml:"ssh_key"`
+	SSHKeyPassphrase      string          `yaml:"ssh_key_passphrase"`
+	SSHCertificate        string          `yaml:"ssh_certificate"`
+	SSHAgentSocket        string          `yaml:"ssh_agent_socket"`
+	SSHAuthMethods        []string        `yaml:"ssh_auth_methods"`
+	SSHKnownHosts         string          `yaml:"ssh_known_hosts"`
+	SSHFingerprint        fingerprintList `yaml:"ssh_fingerprint"`
+	SSHHostCA             string          `yaml:"ssh_host_ca"`
+	SSHRevokedKeys        string          `yaml:"ssh_revoked_keys"`
+	SSHTimeout            string          `yaml:"ssh_timeout"`
+	SSHConnectRetries     *int            `yaml:"ssh_connect_retries"`
+	SSHConnectRetryDelay  string          `yaml:"ssh_connect_retry_delay"`
+	SSHKeepaliveInterval  string          `yaml:"ssh_keepalive_interval"`
+	SSHKeepaliveMaxMissed *int            `yaml:"ssh_keepalive_max_missed"`
+	SSHJumpHosts          []JumpHost      `yaml:"ssh_jump_hosts"`
+	SSHJumpKey            string          `yaml:"ssh_jump_key"`
+	SSHJumpKeyPassphrase  string          `yaml:"ssh_jump_key_passphrase"`
+	SSHJumpCertificate    string          `yaml:"ssh_jump_certificate"`
+	SSHJumpKnownHosts     string          `yaml:"ssh_jump_known_hosts"`
+	SSHJumpFingerprint    string          `yaml:"ssh_jump_fingerprint"`
+	ProjectPath           string          `yaml:"project_path"`
+	DeployFile            string          `yaml:"deploy_file"`
+	ExtraFiles            []ExtraFile     `yaml:"extra_files"`
+	TransferMethod        string          `yaml:"transfer_method"`
+	UploadConcurrency     *int            `yaml:"upload_concurrency"`
+	SkipUnchanged         *bool           `yaml:"skip_unchanged"`
+	SyncDelete            *bool           `yaml:"sync_delete"`
+	Mode                  string          `yaml:"mode"`
+	StackName             string          `yaml:"stack_name"`
+	StackDeployTimeout    string          `yaml:"stack_deploy_timeout"`
+	ComposePull           *bool           `yaml:"compose_pull"`
+	ComposeBuild          *bool           `yaml:"compose_build"`
+	ComposeNoDeps         *bool           `yaml:"compose_no_deps"`
+	ComposeTargetServices []string        `yaml:"compose_target_services"`
+	ComposeStrategy       string          `yaml:"compose_strategy"`
+	ComposeHealthTimeout  string          `yaml:"compose_health_timeout"`
+	ComposePullTimeout    string          `yaml:"compose_pull_timeout"`
+	ComposeUpTimeout      string          `yaml:"compose_up_timeout"`
+	ComposeLogTail        *int            `yaml:"compose_log_tail"`
+	StackTaskHistory      *int            `yaml:"stack_task_history"`
+	DockerNetwork         string          `yaml:"docker_network"`
+	DockerNetworkDriver   string          `yaml:"docker_network_driver"`
+	DockerNetworkAttach   *bool           `yaml:"docker_network_attachable"`
+	DockerPrune           string          `yaml:"docker_prune"`
+	RegistryHost          string          `yaml:"registry_host"`
+	RegistryUser          string          `yaml:"registry_user"`
+	RegistryPass          string          `yaml:"registry_pass"`
+	EnableRollback        *bool           `yaml:"enable_rollback"`
+	RollbackOnCancel      *bool           `yaml:"rollback_on_cancel"`
+	EnvVars               envVarList      `yaml:"env_vars"`
+	Verbose               *bool           `yaml:"verbose"`
+	DeployParallelism     *int            `yaml:"deploy_parallelism"`
+	FailFast              *bool           `yaml:"fail_fast"`
+}
+
+type envVarList string
+
+type fingerprintList string
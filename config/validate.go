@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	validAuthMethods     = []string{"key", "agent"}
+	validModes           = []string{"compose", "stack"}
+	validTransferMethods = []string{"sftp", "scp", "tar"}
+	validStrategies      = []string{"recreate", "in-place"}
+	validPruneTypes      = []string{"none", "system", "volumes", "networks", "images", "containers"}
+	validNetworkDrivers  = []string{"bridge", "overlay", "host", "macvlan", "ipvlan", "none"}
+)
+
+func (c DeployConfig) UsesSSHAgent() bool {
+	return c.SSHAgentSocket != "" && slices.Contains(c.SSHAuthMethods, "agent")
+}
+
+func (c DeployConfig) Validate() error {
+	errs := append([]string{}, c.parseProblems...)
+
+	required := func(name, val string) {
+		if strings.TrimSpace(val) == "" {
+			errs = append(errs, fmt.Sprintf("%s is required", name))
+		}
+	}
+	oneOf := func(name, val string, allowed []string) {
+		if !slices.Contains(allowed, val) {
+			errs = append(errs, fmt.Sprintf("%s '%s' is invalid (accepted values: %s)", name, val, strings.Join(allowed, ", ")))
+		}
+	}
+	duration := func(name, val string) {
+		if d, err := time.ParseDuration(val); err != nil {
+			errs = append(errs, fmt.Sprintf("%s '%s' is not a valid duration (e.g. 10s, 1m)", name, val))
+		} else if d < 0 {
+			errs = append(errs, fmt.Sprintf("%s '%s' must not be negative", name, val))
+		}
+	}
+
+	hosts := c.Hosts
+	if len(hosts) == 0 {
+		hosts = []HostConfig{{Host: c.SSHHost}}
+	}
+
+	for _, h := range hosts {
+		hc := c.ForHost(h)
+		prefix := ""
+		if len(hosts) > 1 {
+			prefix = fmt.Sprintf("host '%s': ", h.Host)
+		}
+
+		required(prefix+"ssh_host", hc.SSHHost)
+		required(prefix+"ssh_user", hc.SSHUser)
+		required(prefix+"project_path", hc.ProjectPath)
+
+		if port, err := strconv.Atoi(hc.SSHPort); err != nil || port < 1 || port > 65535 {
+			errs = append(errs, fmt.Sprintf("%sssh_port '%s' must be a number between 1 and 65535", prefix, hc.SSHPort))
+		}
+	}
+
+	for i, j := range c.SSHJumpHosts {
+		name := fmt.Sprintf("ssh_jump_hosts[%d]", i)
+		required(name+".host", j.Host)
+		if j.Port != "" {
+			if port, err := strconv.Atoi(j.Port); err != nil || port < 1 || port > 65535 {
+				errs = append(errs, fmt.Sprintf("%s.port '%s' must be a number between 1 and 65535", name, j.Port))
+			}
+		}
+	}
+
+	if len(c.SSHAuthMethods) == 0 {
+		errs = append(errs, "ssh_auth_methods must list at least one method")
+	}
+	for _, method := range c.SSHAuthMethods {
+		oneOf("ssh_auth_methods", method, validAuthMethods)
+	}
+	if !c.UsesSSHAgent() {
+		required("ssh_key", c.SSHKey)
+	}
+	if c.SSHCertificate != "" && c.SSHKey == "" {
+		errs = append(errs, "ssh_certificate requires ssh_key to be set")
+	}
+	if c.SSHJumpCertificate != "" && c.SSHJumpKey == "" {
+		errs = append(errs, "ssh_jump_certificate requires ssh_jump_key to be set")
+	}
+	required("deploy_file", c.DeployFile)
+	oneOf("transfer_method", c.TransferMethod, validTransferMethods)
+	duration("ssh_timeout", c.SSHTimeout)
+	duration("ssh_connect_retry_delay", c.SSHConnectRetryDelay)
+	duration("ssh_keepalive_interval", c.SSHKeepaliveInterval)
+	if c.SSHConnectRetries < 0 {
+		errs = append(errs, fmt.Sprintf("ssh_connect_retries '%d' must not be negative", c.SSHConnectRetries))
+	}
+	if c.SSHKeepaliveMaxMissed < 1 {
+		errs = append(errs, fmt.Sprintf("ssh_keepalive_max_missed '%d' must be at least 1", c.SSHKeepaliveMaxMissed))
+	}
+	if c.UploadConcurrency < 1 {
+		errs = append(errs, fmt.Sprintf("upload_concurrency '%d' must be at least 1", c.UploadConcurrency))
+	}
+
+	if c.DeployParallelism < 1 {
+		errs = append(errs, fmt.Sprintf("deploy_parallelism '%d' must be at least 1", c.DeployParallelism))
+	}
+
+	oneOf("mode", c.Mode, validModes)
+	switch c.Mode {
+	case "stack":
+		required("stack_name", c.StackName)
+		duration("stack_deploy_timeout", c.StackDeployTimeout)
+		if c.StackTaskHistory < 1 {
+			errs = append(errs, fmt.Sprintf("stack_task_history '%d' must be at least 1", c.StackTaskHistory))
+		}
+	case "compose":
+		oneOf("compose_strategy", c.ComposeStrategy, validStrategies)
+		duration("compose_health_timeout", c.ComposeHealthTimeout)
+		duration("compose_pull_timeout", c.ComposePullTimeout)
+		duration("compose_up_timeout", c.ComposeUpTimeout)
+		if c.ComposeLogTail < 0 {
+			errs = append(errs, fmt.Sprintf("compose_log_tail '%d' must not be negative", c.ComposeLogTail))
+		}
+	}
+
+	oneOf("docker_prune", c.DockerPrune, validPruneTypes)
+	if c.DockerNetwork != "" {
+		oneOf("docker_network_driver", c.DockerNetworkDriver, validNetworkDrivers)
+	}
+
+	registry := []string{c.RegistryHost, c.RegistryUser, c.RegistryPass}
+	if slices.Contains(registry, "") && slices.ContainsFunc(registry, func(s string) bool { return s != "" }) {
+		errs = append(errs, "registry_host, registry_user and registry_pass must be set together")
+	}
+
+	for i, ef := range c.ExtraFiles {
+		if strings.TrimSpace(ef.Src) == "" {
+			errs = append(errs, fmt.Sprintf("extra_files[%d] has no source path", i))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New("validation failed:\n      \u2192 " + strings.Join(errs, "\n      \u2192 "))
+	}
+
+	return nil
+}
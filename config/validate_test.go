@@ -0,0 +1,131 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func validConfig() DeployConfig {
+	cfg := defaultConfig()
+	cfg.SSHHost = "example.com"
+	cfg.SSHUser = "deployer"
+	cfg.SSHKey = "key"
+	cfg.ProjectPath = "/opt/app"
+	return cfg
+}
+
+func TestValidate_ValidConfig(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Errorf("expected valid config, got: %v", err)
+	}
+}
+
+func TestValidate_SingleProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*DeployConfig)
+		expected string
+	}{
+		{"missing host", func(c *DeployConfig) { c.SSHHost = "" }, "ssh_host is required"},
+		{"port out of range", func(c *DeployConfig) { c.SSHPort = "70000" }, "ssh_port '70000'"},
+		{"port not a number", func(c *DeployConfig) { c.SSHPort = "ssh" }, "ssh_port 'ssh'"},
+		{"negative retries", func(c *DeployConfig) { c.SSHConnectRetries = -1 }, "ssh_connect_retries '-1' must not be negative"},
+		{"bad keepalive interval", func(c *DeployConfig) { c.SSHKeepaliveInterval = "often" }, "ssh_keepalive_interval 'often' is not a valid duration"},
+		{"no missed keepalives", func(c *DeployConfig) { c.SSHKeepaliveMaxMissed = 0 }, "ssh_keepalive_max_missed '0' must be at least 1"},
+		{"bad timeout", func(c *DeployConfig) { c.SSHTimeout = "10" }, "ssh_timeout '10' is not a valid duration"},
+		{"invalid mode", func(c *DeployConfig) { c.Mode = "swarm" }, "mode 'swarm' is invalid"},
+		{"stack without name", func(c *DeployConfig) { c.Mode = "stack" }, "stack_name is required"},
+		{"invalid strategy", func(c *DeployConfig) { c.ComposeStrategy = "rolling" }, "compose_strategy 'rolling' is invalid"},
+		{"bad pull timeout", func(c *DeployConfig) { c.ComposePullTimeout = "forever" }, "compose_pull_timeout 'forever' is not a valid duration"},
+		{"bad stack deploy timeout", func(c *DeployConfig) {
+			c.Mode = "stack"
+			c.StackName = "app"
+			c.StackDeployTimeout = "1h30"
+		}, "stack_deploy_timeout '1h30' is not a valid duration"},
+		{"no stack task history", func(c *DeployConfig) {
+			c.Mode = "stack"
+			c.StackName = "app"
+			c.StackTaskHistory = 0
+		}, "stack_task_history '0' must be at least 1"},
+		{"negative health timeout", func(c *DeployConfig) { c.ComposeHealthTimeout = "-5s" }, "must not be negative"},
+		{"invalid prune", func(c *DeployConfig) { c.DockerPrune = "everything" }, "docker_prune 'everything' is invalid"},
+		{"invalid driver", func(c *DeployConfig) {
+			c.DockerNetwork = "net"
+			c.DockerNetworkDriver = "weave"
+		}, "docker_network_driver 'weave' is invalid"},
+		{"partial registry", func(c *DeployConfig) { c.RegistryHost = "ghcr.io" }, "must be set together"},
+		{"jump host port out of range", func(c *DeployConfig) {
+			c.SSHJumpHosts = []JumpHost{{Host: "bastion", Port: "0"}}
+		}, "ssh_jump_hosts[0].port '0'"},
+		{"invalid auth method", func(c *DeployConfig) { c.SSHAuthMethods = []string{"password"} }, "ssh_auth_methods 'password' is invalid"},
+		{"no auth methods", func(c *DeployConfig) { c.SSHAuthMethods = nil }, "ssh_auth_methods must list at least one method"},
+		{"certificate without key", func(c *DeployConfig) {
+			c.SSHKey = ""
+			c.SSHCertificate = "ssh-ed25519-cert-v01@openssh.com AAAA"
+		}, "ssh_certificate requires ssh_key"},
+		{"jump certificate without jump key", func(c *DeployConfig) {
+			c.SSHJumpCertificate = "ssh-ed25519-cert-v01@openssh.com AAAA"
+		}, "ssh_jump_certificate requires ssh_jump_key"},
+		{"invalid transfer method", func(c *DeployConfig) { c.TransferMethod = "rsync" }, "transfer_method 'rsync' is invalid"},
+		{"no upload concurrency", func(c *DeployConfig) { c.UploadConcurrency = 0 }, "upload_concurrency '0' must be at least 1"},
+		{"extra file without source", func(c *DeployConfig) { c.ExtraFiles = []ExtraFile{{Dst: "dir/"}} }, "extra_files[0] has no source path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidate_AggregatesProblems(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("MODE", "stack")
+	t.Setenv("SSH_PORT", "0")
+	t.Setenv("COMPOSE_PULL", "maybe")
+
+	cfg := mustLoadConfig(t)
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+
+	for _, expected := range []string{
+		"COMPOSE_PULL has invalid boolean value 'maybe'",
+		"ssh_host is required",
+		"ssh_user is required",
+		"ssh_key is required",
+		"project_path is required",
+		"ssh_port '0'",
+		"stack_name is required",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got:\n%v", expected, err)
+		}
+	}
+}
+
+func TestValidate_AgentWithoutKey(t *testing.T) {
+	cfg := validConfig()
+	cfg.SSHKey = ""
+	cfg.SSHAgentSocket = "/tmp/agent.sock"
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected agent authentication to satisfy ssh_key, got: %v", err)
+	}
+
+	cfg.SSHAuthMethods = []string{"key"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "ssh_key is required") {
+		t.Errorf("expected ssh_key to be required when the agent is not an auth method, got: %v", err)
+	}
+}
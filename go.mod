@@ -3,8 +3,12 @@ module github.com/alcharra/docker-deploy-action-go
 go 1.24.3
 
 require (
+	github.com/pkg/sftp v1.13.9
 	golang.org/x/crypto v0.39.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
-require golang.org/x/sys v0.33.0 // indirect
+require (
+	github.com/kr/fs v0.1.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+)
@@ -22,7 +22,7 @@ func Cleanup(client *client.Client, cfg config.DeployConfig) {
 	logs.Verbose("Removing all backup directories")
 	logs.VerboseCommandf("%s", cleanupCmd)
 
-	if _, stderr, err := client.RunCommandBuffered(cleanupCmd); err != nil {
+	if _, stderr, err := client.RunIdempotentBuffered(cleanupCmd); err != nil {
 		logs.Warnf("Failed to clean up backup directories: %v\nDetails: %s", err, stderr)
 		return
 	}
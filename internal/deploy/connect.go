@@ -1,17 +1,23 @@
 package deploy
 
 import (
+	"context"
+	"net"
+
 	"github.com/alcharra/docker-deploy-action-go/config"
 	"github.com/alcharra/docker-deploy-action-go/internal/logs"
 	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
 )
 
-func ConnectToSSH(cfg config.DeployConfig) *client.Client {
+func ConnectToSSH(ctx context.Context, cfg config.DeployConfig) *client.Client {
 	logs.Step("\U0001F50C Connecting to remote server...")
 	logs.Substepf("\u2022 Host: %s", cfg.SSHHost)
 	logs.Substepf("\u2022 User: %s", cfg.SSHUser)
+	for _, jump := range cfg.SSHJumpHosts {
+		logs.Substepf("\u2022 Via: %s", jumpHostLabel(jump))
+	}
 
-	cli, err := client.NewClient(cfg)
+	cli, err := client.NewClient(ctx, cfg)
 	if err != nil {
 		logs.Fatalf("Unable to establish SSH connection: %v", err)
 	}
@@ -19,3 +25,14 @@ func ConnectToSSH(cfg config.DeployConfig) *client.Client {
 	logs.Success("SSH connection established")
 	return cli
 }
+
+func jumpHostLabel(jump config.JumpHost) string {
+	label := jump.Host
+	if jump.Port != "" {
+		label = net.JoinHostPort(label, jump.Port)
+	}
+	if jump.User != "" {
+		label = jump.User + "@" + label
+	}
+	return label
+}
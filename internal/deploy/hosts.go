@@ -0,0 +1,217 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/config"
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+)
+
+const (
+	hostStatusSucceeded   = "succeeded"
+	hostStatusFailed      = "failed"
+	hostStatusSkipped     = "skipped"
+	hostStatusInterrupted = "interrupted"
+)
+
+func DeployToHosts(cfg config.DeployConfig) {
+	logs.IsVerbose = cfg.Verbose
+
+	parallelism := max(cfg.DeployParallelism, 1)
+
+	logs.Stepf("\U0001F30D Deploying to %d hosts...", len(cfg.Hosts))
+	logs.Substepf("\u2022 Parallelism: %d", parallelism)
+	logs.Substepf("\u2022 Fail fast: %t", cfg.FailFast)
+
+	executable, err := os.Executable()
+	if err != nil {
+		logs.Fatalf("Unable to locate deploy binary: %v", err)
+	}
+
+	results := make([]HostResult, len(cfg.Hosts))
+	sem := make(chan struct{}, parallelism)
+	procs := newHostProcesses()
+	var failed atomic.Bool
+	var outputMu sync.Mutex
+	var wg sync.WaitGroup
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, interruptSignals...)
+	defer signal.Stop(signals)
+	go func() {
+		for sig := range signals {
+			procs.interrupt(sig)
+		}
+	}()
+
+	for i, host := range cfg.Hosts {
+		sem <- struct{}{}
+
+		if (cfg.FailFast && failed.Load()) || procs.interrupted() != nil {
+			<-sem
+			results[i] = HostResult{Host: hostLabel(cfg.ForHost(host)), Status: hostStatusSkipped}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, hostCfg config.DeployConfig) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i] = runHostDeployment(executable, hostCfg, parallelism > 1, &outputMu, procs)
+			if results[i].Err != nil {
+				failed.Store(true)
+			}
+		}(i, cfg.ForHost(host))
+	}
+
+	wg.Wait()
+
+	printHostResults(results)
+
+	if sig := procs.interrupted(); sig != nil {
+		logs.Stepf("\U0001F6D1 Deployment interrupted by %s", signalName(sig))
+		os.Exit(interruptExitCode(sig))
+	}
+
+	var failures, skipped int
+	for _, r := range results {
+		switch r.Status {
+		case hostStatusFailed, hostStatusInterrupted:
+			failures++
+		case hostStatusSkipped:
+			skipped++
+		}
+	}
+	if failures > 0 {
+		if skipped > 0 {
+			logs.Fatalf("Deployment failed on %d of %d host%s (%d skipped)", failures, len(results), utils.Plural(len(results)), skipped)
+		}
+		logs.Fatalf("Deployment failed on %d of %d host%s", failures, len(results), utils.Plural(len(results)))
+	}
+}
+
+func runHostDeployment(executable string, cfg config.DeployConfig, buffered bool, outputMu *sync.Mutex, procs *hostProcesses) HostResult {
+	label := hostLabel(cfg)
+
+	cmd := exec.Command(executable)
+	cmd.Env = append(os.Environ(),
+		"SSH_HOST="+cfg.SSHHost,
+		"SSH_PORT="+cfg.SSHPort,
+		"SSH_USER="+cfg.SSHUser,
+		"PROJECT_PATH="+cfg.ProjectPath,
+		logs.NestedEnv+"=true",
+	)
+
+	var buf bytes.Buffer
+	var out io.Writer = os.Stdout
+	if buffered {
+		out = &buf
+	} else {
+		outputMu.Lock()
+		defer outputMu.Unlock()
+		logs.Group(fmt.Sprintf("\U0001F5A5\U0000FE0F %s", label))
+		defer logs.EndGroup()
+	}
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	start := time.Now()
+	err := procs.run(cmd)
+	result := HostResult{Host: label, Status: hostStatusSucceeded, Duration: time.Since(start), Err: err}
+	switch {
+	case err != nil && procs.interrupted() != nil:
+		result.Status = hostStatusInterrupted
+	case err != nil:
+		result.Status = hostStatusFailed
+	}
+
+	if buffered {
+		outputMu.Lock()
+		logs.Group(fmt.Sprintf("\U0001F5A5\U0000FE0F %s (%s)", label, result.Status))
+		os.Stdout.Write(buf.Bytes())
+		logs.EndGroup()
+		outputMu.Unlock()
+	}
+
+	return result
+}
+
+func hostLabel(cfg config.DeployConfig) string {
+	return fmt.Sprintf("%s@%s:%s", cfg.SSHUser, cfg.SSHHost, cfg.SSHPort)
+}
+
+func printHostResults(results []HostResult) {
+	maxHostLen := 0
+	for _, r := range results {
+		maxHostLen = max(maxHostLen, len(r.Host))
+	}
+
+	logs.Step("\U0001F4CB Host results...")
+	for _, r := range results {
+		host := fmt.Sprintf("%-*s", maxHostLen, r.Host)
+		switch r.Status {
+		case hostStatusSucceeded:
+			logs.Successf("%s  %s  %s(%s)%s", host, r.Status, logs.GrayColor, r.Duration.Round(time.Second), logs.ResetColor)
+		case hostStatusFailed, hostStatusInterrupted:
+			logs.Errorf("%s  %s  %s(%s, %v)%s", host, r.Status, logs.GrayColor, r.Duration.Round(time.Second), r.Err, logs.ResetColor)
+		default:
+			logs.Warnf("%s  %s", host, r.Status)
+		}
+	}
+}
+
+func newHostProcesses() *hostProcesses {
+	return &hostProcesses{running: map[*exec.Cmd]struct{}{}}
+}
+
+func (p *hostProcesses) run(cmd *exec.Cmd) error {
+	p.mu.Lock()
+	if p.signal != nil {
+		p.mu.Unlock()
+		return fmt.Errorf("deployment interrupted by %s", signalName(p.signal))
+	}
+	if err := cmd.Start(); err != nil {
+		p.mu.Unlock()
+		return err
+	}
+	p.running[cmd] = struct{}{}
+	p.mu.Unlock()
+
+	err := cmd.Wait()
+
+	p.mu.Lock()
+	delete(p.running, cmd)
+	p.mu.Unlock()
+	return err
+}
+
+// Each host runs in its own process with its own interrupt handling, so the
+// parent only forwards the signal and waits for them to wind down.
+func (p *hostProcesses) interrupt(sig os.Signal) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.signal == nil {
+		p.signal = sig
+		logs.Stepf("\U0001F6D1 Received %s – stopping %d running deployment%s...", signalName(sig), len(p.running), utils.Plural(len(p.running)))
+	}
+	for cmd := range p.running {
+		cmd.Process.Signal(sig)
+	}
+}
+
+func (p *hostProcesses) interrupted() os.Signal {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.signal
+}
@@ -0,0 +1,220 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/config"
+	"github.com/alcharra/docker-deploy-action-go/internal/docker"
+	"github.com/alcharra/docker-deploy-action-go/internal/files"
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+)
+
+const (
+	StageConnect  = "SSH connection"
+	StageBackup   = "Backup"
+	StageUpload   = "File upload"
+	StageVerify   = "File verification"
+	StageDocker   = "Docker checks"
+	StageNetwork  = "Network setup"
+	StageRegistry = "Registry login"
+	StageDeploy   = "Deployment"
+	StagePrune    = "Prune"
+	StageSync     = "Stale file removal"
+	StageCleanup  = "Cleanup"
+)
+
+const interruptWait = 10 * time.Second
+
+var (
+	interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+	// Stages that may leave the remote host half-updated if cut short.
+	rollbackStages = map[string][]string{
+		"compose": {StageUpload, StageVerify, StageDocker, StageNetwork, StageRegistry, StageDeploy},
+		"stack":   {StageDeploy},
+	}
+)
+
+func WatchInterrupts(cfg config.DeployConfig) (context.Context, *Interrupts) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	in := &Interrupts{
+		cfg:     cfg,
+		cancel:  cancel,
+		signals: make(chan os.Signal, 1),
+		stopped: make(chan struct{}),
+		started: time.Now(),
+	}
+	signal.Notify(in.signals, interruptSignals...)
+	go in.watch()
+
+	return ctx, in
+}
+
+func (in *Interrupts) Attach(cli *client.Client) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.cli = cli
+}
+
+func (in *Interrupts) Stage(name string, cfg config.DeployConfig) {
+	in.mu.Lock()
+	interrupted := in.interrupted
+	if !interrupted {
+		if in.stage != "" {
+			in.completed = append(in.completed, in.stage)
+		}
+		in.stage = name
+		in.cfg = cfg
+	}
+	in.mu.Unlock()
+
+	if interrupted {
+		logs.Park()
+	}
+}
+
+func (in *Interrupts) Finish() {
+	in.mu.Lock()
+	interrupted := in.interrupted
+	if !interrupted {
+		in.finished = true
+		signal.Stop(in.signals)
+		close(in.stopped)
+	}
+	in.mu.Unlock()
+
+	if interrupted {
+		logs.Park()
+	}
+}
+
+func (in *Interrupts) watch() {
+	select {
+	case sig := <-in.signals:
+		in.handle(sig)
+	case <-in.stopped:
+	}
+}
+
+func (in *Interrupts) handle(sig os.Signal) {
+	in.mu.Lock()
+	if in.finished {
+		in.mu.Unlock()
+		return
+	}
+	in.interrupted = true
+	cfg, cli, stage, completed := in.cfg, in.cli, in.stage, slices.Clone(in.completed)
+	in.mu.Unlock()
+
+	logs.Halt()
+	go func() {
+		for range in.signals {
+			logs.Warn("Already stopping – waiting for shutdown to finish")
+		}
+	}()
+
+	name := signalName(sig)
+	logs.Stepf("\U0001F6D1 Received %s – stopping deployment...", name)
+	in.cancel(fmt.Errorf("deployment interrupted by %s", name))
+
+	if cli != nil {
+		select {
+		case <-logs.Parked():
+		case <-time.After(interruptWait):
+			logs.Warn("In-flight command did not stop in time")
+		}
+	}
+
+	rollback := rollbackInterrupted(cli, cfg, stage)
+	printInterruptSummary(cfg, name, stage, completed, rollback, time.Since(in.started))
+
+	if cli != nil {
+		cli.Close()
+	}
+	os.Exit(interruptExitCode(sig))
+}
+
+func rollbackInterrupted(cli *client.Client, cfg config.DeployConfig, stage string) string {
+	if cli == nil || !slices.Contains(rollbackStages[cfg.Mode], stage) {
+		return "not needed"
+	}
+	if !cfg.EnableRollback || !cfg.RollbackOnCancel {
+		logs.Warn("Rollback is disabled – the remote host may be partially updated")
+		return "skipped"
+	}
+
+	// The deployment context is already cancelled; roll back on a fresh one.
+	cli = cli.WithContext(context.Background())
+
+	var err error
+	switch cfg.Mode {
+	case "compose":
+		err = files.RestoreBackup(cli, cfg.ProjectPath)
+		if err == nil && stage == StageDeploy && cfg.ComposeBinary != "" {
+			err = docker.RedeployCompose(cli, cfg)
+		}
+	case "stack":
+		err = docker.RollbackStack(cli, cfg)
+	}
+
+	if err != nil {
+		logs.Errorf("Rollback failed: %v", err)
+		return "failed"
+	}
+	logs.Success("Rollback completed")
+	return "completed"
+}
+
+func printInterruptSummary(cfg config.DeployConfig, sigName, stage string, completed []string, rollback string, elapsed time.Duration) {
+	if stage == "" {
+		stage = "startup"
+	}
+	done := "none"
+	if len(completed) > 0 {
+		done = strings.Join(completed, ", ")
+	}
+
+	logs.Step("\U0001F4CB Deployment interrupted")
+	logs.Substepf("\u2022 Host: %s", hostLabel(cfg))
+	logs.Substepf("\u2022 Signal: %s", sigName)
+	logs.Substepf("\u2022 Stopped during: %s", stage)
+	logs.Substepf("\u2022 Completed: %s", done)
+	logs.Substepf("\u2022 Rollback: %s", rollback)
+	logs.Substepf("\u2022 Elapsed: %s", elapsed.Round(time.Second))
+
+	var summary strings.Builder
+	summary.WriteString("### \U0001F6D1 Deployment interrupted\n\n")
+	summary.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&summary, "| Host | `%s` |\n", hostLabel(cfg))
+	fmt.Fprintf(&summary, "| Signal | %s |\n", sigName)
+	fmt.Fprintf(&summary, "| Stopped during | %s |\n", stage)
+	fmt.Fprintf(&summary, "| Completed | %s |\n", done)
+	fmt.Fprintf(&summary, "| Rollback | %s |\n", rollback)
+	logs.Summary(summary.String())
+}
+
+func signalName(sig os.Signal) string {
+	switch sig {
+	case os.Interrupt:
+		return "SIGINT"
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	}
+	return sig.String()
+}
+
+func interruptExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
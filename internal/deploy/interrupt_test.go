@@ -0,0 +1,66 @@
+//go:build unit
+// +build unit
+
+package deploy
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/alcharra/docker-deploy-action-go/config"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+)
+
+func TestRollbackInterrupted_NotNeeded(t *testing.T) {
+	cli := &client.Client{}
+
+	tests := []struct {
+		name  string
+		cli   *client.Client
+		mode  string
+		stage string
+	}{
+		{"not connected", nil, "compose", StageConnect},
+		{"compose before upload", cli, "compose", StageBackup},
+		{"compose after deploy", cli, "compose", StagePrune},
+		{"stack before deploy", cli, "stack", StageUpload},
+		{"stack after deploy", cli, "stack", StageCleanup},
+	}
+
+	for _, tt := range tests {
+		cfg := config.DeployConfig{Mode: tt.mode, EnableRollback: true, RollbackOnCancel: true}
+		if got := rollbackInterrupted(tt.cli, cfg, tt.stage); got != "not needed" {
+			t.Errorf("%s: expected 'not needed', got %q", tt.name, got)
+		}
+	}
+}
+
+func TestRollbackInterrupted_Disabled(t *testing.T) {
+	cli := &client.Client{}
+
+	tests := []struct {
+		name string
+		cfg  config.DeployConfig
+	}{
+		{"rollback disabled", config.DeployConfig{Mode: "compose", EnableRollback: false, RollbackOnCancel: true}},
+		{"rollback on cancel disabled", config.DeployConfig{Mode: "stack", EnableRollback: true, RollbackOnCancel: false}},
+	}
+
+	for _, tt := range tests {
+		if got := rollbackInterrupted(cli, tt.cfg, StageDeploy); got != "skipped" {
+			t.Errorf("%s: expected 'skipped', got %q", tt.name, got)
+		}
+	}
+}
+
+func TestInterruptExitCode(t *testing.T) {
+	if got := interruptExitCode(syscall.SIGINT); got != 130 {
+		t.Errorf("SIGINT: expected 130, got %d", got)
+	}
+	if got := interruptExitCode(syscall.SIGTERM); got != 143 {
+		t.Errorf("SIGTERM: expected 143, got %d", got)
+	}
+	if got := signalName(syscall.SIGTERM); got != "SIGTERM" {
+		t.Errorf("expected SIGTERM, got %s", got)
+	}
+}
@@ -0,0 +1,39 @@
+package deploy
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/config"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+)
+
+type HostResult struct {
+	Host     string
+	Status   string
+	Duration time.Duration
+	Err      error
+}
+
+type Interrupts struct {
+	mu          sync.Mutex
+	cfg         config.DeployConfig
+	cli         *client.Client
+	stage       string
+	completed   []string
+	started     time.Time
+	cancel      context.CancelCauseFunc
+	signals     chan os.Signal
+	stopped     chan struct{}
+	interrupted bool
+	finished    bool
+}
+
+type hostProcesses struct {
+	mu      sync.Mutex
+	running map[*exec.Cmd]struct{}
+	signal  os.Signal
+}
@@ -41,7 +41,7 @@ func CheckDockerInstalled(cli *client.Client) {
 		fi
 	`
 
-	stdout, stderr, err := cli.RunCommandBuffered(cmd)
+	stdout, stderr, err := cli.RunIdempotentBuffered(cmd)
 	if err != nil {
 		logs.Fatalf("Unable to verify Docker installation: %v\nDetails: %s", err, stderr)
 	}
@@ -68,7 +68,7 @@ func CheckSwarmMode(cli *client.Client) {
 		fi
 	`
 
-	stdout, stderr, err := cli.RunCommandBuffered(cmd)
+	stdout, stderr, err := cli.RunIdempotentBuffered(cmd)
 	if err != nil {
 		logs.Fatalf("Unable to verify Swarm mode: %v\nDetails: %s", err, stderr)
 	}
@@ -97,7 +97,7 @@ func CheckComposeAvailable(cli *client.Client, cfg *config.DeployConfig) {
 		fi
 	`
 
-	stdout, stderr, err := cli.RunCommandBuffered(cmd)
+	stdout, stderr, err := cli.RunIdempotentBuffered(cmd)
 	if err != nil {
 		logs.Fatalf("Unable to verify Docker Compose availability: %v\nDetails: %s", err, stderr)
 	}
@@ -24,6 +24,12 @@ func DeployDockerCompose(cli *client.Client, cfg config.DeployConfig) {
 
 	compose := cfg.ComposeBinary
 	composeFilePath := path.Join(cfg.ProjectPath, path.Base(cfg.DeployFile))
+	services := cfg.ComposeTargetServices
+
+	healthTimeout, err := time.ParseDuration(cfg.ComposeHealthTimeout)
+	if err != nil {
+		logs.Fatalf("Invalid compose health timeout '%s': %v", cfg.ComposeHealthTimeout, err)
+	}
 
 	if !cfg.RollbackTriggered {
 		validateComposeConfig(cli, compose, composeFilePath)
@@ -35,24 +41,45 @@ func DeployDockerCompose(cli *client.Client, cfg config.DeployConfig) {
 		logs.Step("\U0001F433 Deploying with Docker Compose...")
 	}
 
+	if len(services) > 0 {
+		logs.Substepf("\U0001F3AF Targeting service%s: %s", utils.Plural(len(services)), strings.Join(services, ", "))
+	}
+
 	if cfg.ComposePull {
-		pullImages(cli, compose, composeFilePath)
+		pullImages(cli, compose, composeFilePath, services, cfg.ComposePullTimeout)
 	} else if logs.IsVerbose {
 		logs.Verbose("Skipping image pull as ComposePull is disabled")
 	}
 
-	stopServices(cli, compose, composeFilePath)
-	if err := startServices(cli, compose, composeFilePath, buildComposeFlags(cfg)); err != nil {
+	switch cfg.ComposeStrategy {
+	case "in-place":
+		logs.Verbose("Skipping service shutdown (in-place strategy)")
+	case "recreate", "":
+		stopServices(cli, compose, composeFilePath, services)
+	default:
+		logs.Fatalf("Invalid compose strategy: '%s'. Accepted values are: recreate or in-place.", cfg.ComposeStrategy)
+	}
+
+	if err := startServices(cli, compose, composeFilePath, buildComposeFlags(cfg), services, cfg.ComposeUpTimeout); err != nil {
 		handleComposeFailure(cli, cfg, err.Error())
 		return
 	}
 
 	logs.Substep("\U0001F433 Docker Compose deployment completed successfully")
 
-	if err := checkServiceStatus(cli, compose, composeFilePath); err != nil {
+	if failed, err := checkServiceStatus(cli, compose, composeFilePath, services); err != nil {
+		printFailedServiceLogs(cli, compose, composeFilePath, failed, cfg.ComposeLogTail)
 		handleComposeFailure(cli, cfg, err.Error())
 		return
 	}
+
+	if healthTimeout > 0 {
+		if failed, err := waitForHealthy(cli, compose, composeFilePath, services, healthTimeout); err != nil {
+			printFailedServiceLogs(cli, compose, composeFilePath, failed, cfg.ComposeLogTail)
+			handleComposeFailure(cli, cfg, err.Error())
+			return
+		}
+	}
 }
 
 func validateComposeConfig(cli *client.Client, compose, filePath string) {
@@ -62,7 +89,7 @@ func validateComposeConfig(cli *client.Client, compose, filePath string) {
 	cmd := fmt.Sprintf(`%s -f "%s" config`, compose, filePath)
 	logs.VerboseCommandf("%s", cmd)
 
-	if _, stderr, err := cli.RunCommandBuffered(cmd); err != nil {
+	if _, stderr, err := cli.RunIdempotentBuffered(cmd); err != nil {
 		cleaned := strings.ReplaceAll(strings.TrimSpace(stderr), "\n", " ")
 		logs.Error("Compose file validation failed")
 		logs.Fatalf("%s", cleaned)
@@ -71,29 +98,47 @@ func validateComposeConfig(cli *client.Client, compose, filePath string) {
 	logs.Success("Compose file is valid")
 }
 
-func pullImages(cli *client.Client, compose, filePath string) {
+func pullImages(cli *client.Client, compose, filePath string, services []string, timeout string) {
 	logs.Verbose("Pulling latest images...")
-	cmd := fmt.Sprintf(`%s -f "%s" pull`, compose, filePath)
+	cmd := fmt.Sprintf(`%s -f "%s" pull%s`, compose, filePath, serviceArgs(services))
 	logs.VerboseCommandf("%s", cmd)
-	if err := cli.RunCommandStreamed(cmd); err != nil {
+
+	ctx, cancel := phaseContext(cli.Context(), "compose pull", timeout)
+	defer cancel()
+
+	if err := cli.RunCommandStreamedContext(ctx, cmd); err != nil {
 		logs.Fatalf("Pull failed: %v", err)
 	}
 }
 
-func stopServices(cli *client.Client, compose, filePath string) {
-	logs.Verbose("Stopping existing services...")
-	cmd := fmt.Sprintf(`%s -f "%s" down`, compose, filePath)
+func stopServices(cli *client.Client, compose, filePath string, services []string) {
+	var cmd string
+	if len(services) > 0 {
+		logs.Verbose("Stopping targeted services...")
+		cmd = fmt.Sprintf(`%s -f "%s" rm -s -f%s`, compose, filePath, serviceArgs(services))
+	} else {
+		logs.Verbose("Stopping existing services...")
+		cmd = fmt.Sprintf(`%s -f "%s" down`, compose, filePath)
+	}
 	logs.VerboseCommandf("%s", cmd)
 	if err := cli.RunCommandStreamed(cmd); err != nil {
 		logs.Fatalf("Failed to stop services: %v", err)
 	}
 }
 
-func startServices(cli *client.Client, compose, filePath, flags string) error {
-	logs.Verbose("Starting all services...")
-	cmd := fmt.Sprintf(`%s -f "%s" up %s`, compose, filePath, flags)
+func startServices(cli *client.Client, compose, filePath, flags string, services []string, timeout string) error {
+	if len(services) > 0 {
+		logs.Verbose("Starting targeted services...")
+	} else {
+		logs.Verbose("Starting all services...")
+	}
+	cmd := fmt.Sprintf(`%s -f "%s" up %s%s`, compose, filePath, flags, serviceArgs(services))
 	logs.VerboseCommandf("%s", cmd)
-	return cli.RunCommandStreamed(cmd)
+
+	ctx, cancel := phaseContext(cli.Context(), "compose up", timeout)
+	defer cancel()
+
+	return cli.RunCommandStreamedContext(ctx, cmd)
 }
 
 func buildComposeFlags(cfg config.DeployConfig) string {
@@ -105,58 +150,75 @@ func buildComposeFlags(cfg config.DeployConfig) string {
 	if cfg.ComposeNoDeps {
 		flags = append(flags, "--no-deps")
 	}
+	if cfg.ComposeStrategy == "in-place" {
+		flags = append(flags, "--remove-orphans")
+	}
 	return strings.Join(flags, " ")
 }
 
-func checkServiceStatus(cli *client.Client, compose, filePath string) error {
+func serviceArgs(services []string) string {
+	var args strings.Builder
+	for _, svc := range services {
+		fmt.Fprintf(&args, ` "%s"`, svc)
+	}
+	return args.String()
+}
+
+func checkServiceStatus(cli *client.Client, compose, filePath string, services []string) ([]ComposeContainer, error) {
 	logs.Step("\U0001F50E Validating Docker Compose status...")
 	logs.Verbose("Checking container status after deployment...")
 
-	cmd := fmt.Sprintf(`%s -f "%s" ps`, compose, filePath)
-	logs.VerboseCommandf("%s", cmd)
-
 	time.Sleep(1 * time.Second)
 
-	out, _, err := cli.RunCommandBuffered(cmd)
+	containers, err := listComposeContainers(cli.Context(), cli, compose, filePath, services)
 	if err != nil {
-		return fmt.Errorf("failed to inspect services: %v", err)
+		return nil, fmt.Errorf("failed to inspect services: %v", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(out), "\n")
-	if len(lines) <= 1 {
-		logs.Warn("No container lines found in `docker compose ps` output")
-		return fmt.Errorf("no containers found to verify")
+	if len(containers) == 0 {
+		logs.Warn("No containers found in `docker compose ps` output")
+		return nil, fmt.Errorf("no containers found to verify")
 	}
 
-	var failedContainers []string
-
-	for _, line := range lines[1:] {
-		fields := strings.Fields(line)
-		if len(fields) < 5 {
-			continue
-		}
+	var failedContainers []ComposeContainer
 
-		status := strings.ToLower(strings.Join(fields[4:], " "))
-		if strings.Contains(status, "exit") || strings.Contains(status, "dead") || strings.Contains(status, "restarting") {
-			failedContainers = append(failedContainers, fmt.Sprintf("      \u2192 %s", line))
+	for _, c := range containers {
+		if c.Failed() {
+			failedContainers = append(failedContainers, c)
 		}
 	}
 
 	if len(failedContainers) > 0 {
 		logs.Substepf("\u2022 Container check failed for %d container%s", len(failedContainers), utils.Plural(len(failedContainers)))
-		for _, msg := range failedContainers {
-			fmt.Println(msg)
+		for _, c := range failedContainers {
+			fmt.Printf("      \u2192 %s\n", c)
 		}
-		return fmt.Errorf("One or more containers failed to start")
+		return failedContainers, fmt.Errorf("One or more containers failed to start")
 	}
 
 	logs.Success("All containers are running as expected")
+	return nil, nil
+}
+
+func RedeployCompose(cli *client.Client, cfg config.DeployConfig) error {
+	logs.Step("\U0001F501 Re-deploying after rollback...")
+
+	composeFilePath := path.Join(cfg.ProjectPath, path.Base(cfg.DeployFile))
+	if err := startServices(cli, cfg.ComposeBinary, composeFilePath, buildComposeFlags(cfg), cfg.ComposeTargetServices, cfg.ComposeUpTimeout); err != nil {
+		return fmt.Errorf("failed to start services: %w", err)
+	}
+
+	logs.Success("Services re-deployed from backup")
 	return nil
 }
 
 func handleComposeFailure(cli *client.Client, cfg config.DeployConfig, reason string) {
 	logs.Error(reason)
 
+	if cli.Context().Err() != nil {
+		logs.Fatalf("Deployment interrupted")
+	}
+
 	if cfg.EnableRollback && !cfg.RollbackTriggered {
 		cfg.RollbackTriggered = true
 
@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+)
+
+func printFailedServiceLogs(cli *client.Client, compose, filePath string, containers []ComposeContainer, tail int) {
+	if tail <= 0 || len(containers) == 0 {
+		return
+	}
+
+	var services []string
+	seen := map[string]bool{}
+	for _, c := range containers {
+		if c.Service == "" || seen[c.Service] {
+			continue
+		}
+		seen[c.Service] = true
+		services = append(services, c.Service)
+	}
+
+	logs.Step("\U0001F4DC Collecting logs of failed services...")
+
+	var summary strings.Builder
+	summary.WriteString("### \u274C Failed Compose services\n")
+
+	for _, svc := range services {
+		cmd := fmt.Sprintf(`%s -f "%s" logs --no-color --tail %d "%s"`, compose, filePath, tail, svc)
+		logs.VerboseCommandf("%s", cmd)
+
+		stdout, stderr, err := cli.RunIdempotentBuffered(cmd)
+		if err != nil {
+			logs.Warnf("Could not fetch logs for %s: %v\nDetails: %s", svc, err, strings.TrimSpace(stderr))
+			continue
+		}
+
+		output := strings.TrimRight(stdout+stderr, "\n")
+		if output == "" {
+			output = "(no log output)"
+		}
+
+		logs.Group(fmt.Sprintf("Logs for service '%s' (last %d lines)", svc, tail))
+		logs.Untrusted(output)
+		logs.EndGroup()
+
+		fence := codeFence(output)
+		fmt.Fprintf(&summary, "\n<details>\n<summary>%s (last %d lines)</summary>\n\n%s\n%s\n%s\n\n</details>\n", svc, tail, fence, output, fence)
+	}
+
+	logs.Summary(summary.String())
+}
+
+// codeFence returns a backtick fence longer than any run of backticks in
+// content, so the logs cannot close it early.
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
@@ -0,0 +1,24 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import "testing"
+
+func TestCodeFence(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"plain log line", "```"},
+		{"uses `inline` code", "```"},
+		{"```\n# injected heading\n```", "````"},
+		{"`````` and ```", "```````"},
+	}
+
+	for _, tt := range tests {
+		if got := codeFence(tt.content); got != tt.expected {
+			t.Errorf("codeFence(%q) = %q, expected %q", tt.content, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+)
+
+var (
+	legacyColumnSeparator = regexp.MustCompile(`\s{2,}`)
+	legacyStatePattern    = regexp.MustCompile(`(?i)^(up|running|exit|restarting|dead|paused|created)\b`)
+	legacyExitCodePattern = regexp.MustCompile(`^exit(?:ed)?\s*\(?(-?\d+)\)?`)
+)
+
+func listComposeContainers(ctx context.Context, cli *client.Client, compose, filePath string, services []string) ([]ComposeContainer, error) {
+	cmd := fmt.Sprintf(`%s -f "%s" ps -a --format json%s`, compose, filePath, serviceArgs(services))
+	logs.VerboseCommandf("%s", cmd)
+
+	stdout, stderr, err := cli.RunIdempotentBufferedContext(ctx, cmd)
+	if err == nil {
+		return parseComposePSJSON(stdout)
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+	if !formatUnsupported(stderr) {
+		return nil, fmt.Errorf("%v\nDetails: %s", err, strings.TrimSpace(stderr))
+	}
+
+	logs.Verbose("JSON output is not supported by this Compose version, falling back to table output")
+
+	cmd = fmt.Sprintf(`%s -f "%s" ps -a%s`, compose, filePath, serviceArgs(services))
+	logs.VerboseCommandf("%s", cmd)
+
+	stdout, stderr, err = cli.RunIdempotentBufferedContext(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%v\nDetails: %s", err, strings.TrimSpace(stderr))
+	}
+
+	containers := parseComposePSTable(stdout)
+	labelServices(ctx, cli, containers)
+	return containers, nil
+}
+
+// formatUnsupported reports whether ps failed because this Compose version has
+// no --format flag. docker-compose v1 rejects it with its usage text.
+func formatUnsupported(stderr string) bool {
+	lower := strings.ToLower(stderr)
+	return strings.Contains(lower, "--format") || strings.Contains(lower, "usage:")
+}
+
+// labelServices fills in each container's service from its Compose label, as
+// legacy container names cannot be split reliably when the project name
+// contains '-' or '_'.
+func labelServices(ctx context.Context, cli *client.Client, containers []ComposeContainer) {
+	if len(containers) == 0 {
+		return
+	}
+
+	names := make([]string, len(containers))
+	for i, c := range containers {
+		names[i] = utils.ShellQuote(c.Name)
+	}
+	cmd := fmt.Sprintf(`docker inspect --format '{{.Name}} {{index .Config.Labels "com.docker.compose.service"}}' %s`, strings.Join(names, " "))
+	logs.VerboseCommandf("%s", cmd)
+
+	stdout, stderr, err := cli.RunIdempotentBufferedContext(ctx, cmd)
+	if err != nil {
+		logs.Verbosef("Unable to read service labels: %v %s", err, strings.TrimSpace(stderr))
+		return
+	}
+
+	services := parseServiceLabels(stdout)
+	for i := range containers {
+		containers[i].Service = services[containers[i].Name]
+	}
+}
+
+func parseServiceLabels(out string) map[string]string {
+	services := map[string]string{}
+	for line := range strings.SplitSeq(out, "\n") {
+		name, service, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || service == "" {
+			continue
+		}
+		services[strings.TrimPrefix(name, "/")] = strings.TrimSpace(service)
+	}
+	return services
+}
+
+func parseComposePSJSON(out string) ([]ComposeContainer, error) {
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return nil, nil
+	}
+
+	var entries []composePSEntry
+
+	if strings.HasPrefix(out, "[") {
+		if err := json.Unmarshal([]byte(out), &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse compose ps output: %w", err)
+		}
+	} else {
+		for line := range strings.SplitSeq(out, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+
+			var entry composePSEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				return nil, fmt.Errorf("failed to parse compose ps output: %w", err)
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	containers := make([]ComposeContainer, 0, len(entries))
+	for _, entry := range entries {
+		var ports []string
+		for _, p := range entry.Publishers {
+			switch {
+			case p.PublishedPort != 0 && p.URL != "":
+				ports = append(ports, fmt.Sprintf("%s:%d->%d/%s", p.URL, p.PublishedPort, p.TargetPort, p.Protocol))
+			case p.PublishedPort != 0:
+				ports = append(ports, fmt.Sprintf("%d->%d/%s", p.PublishedPort, p.TargetPort, p.Protocol))
+			default:
+				ports = append(ports, fmt.Sprintf("%d/%s", p.TargetPort, p.Protocol))
+			}
+		}
+
+		containers = append(containers, ComposeContainer{
+			Name:     entry.Name,
+			Service:  entry.Service,
+			State:    strings.ToLower(entry.State),
+			Health:   strings.ToLower(entry.Health),
+			ExitCode: entry.ExitCode,
+			Ports:    ports,
+		})
+	}
+
+	return containers, nil
+}
+
+func parseComposePSTable(out string) []ComposeContainer {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+
+	headerIdx := -1
+	for i, line := range lines {
+		upper := strings.ToUpper(line)
+		if strings.Contains(upper, "NAME") && (strings.Contains(upper, "STATE") || strings.Contains(upper, "STATUS")) {
+			headerIdx = i
+			break
+		}
+	}
+	if headerIdx < 0 {
+		return nil
+	}
+
+	var containers []ComposeContainer
+
+	for _, line := range lines[headerIdx+1:] {
+		line = strings.TrimSpace(line)
+		if strings.Trim(line, "-") == "" {
+			continue
+		}
+
+		cols := legacyColumnSeparator.Split(line, -1)
+		name := cols[0]
+
+		var status string
+		var ports []string
+		for i := 1; i < len(cols); i++ {
+			if !legacyStatePattern.MatchString(cols[i]) {
+				continue
+			}
+			status = cols[i]
+			for _, col := range cols[i+1:] {
+				for p := range strings.SplitSeq(col, ",") {
+					if p = strings.TrimSpace(p); p != "" {
+						ports = append(ports, p)
+					}
+				}
+			}
+			break
+		}
+
+		state, health, exitCode := parseLegacyStatus(status)
+
+		containers = append(containers, ComposeContainer{
+			Name:     name,
+			State:    state,
+			Health:   health,
+			ExitCode: exitCode,
+			Ports:    ports,
+		})
+	}
+
+	return containers
+}
+
+func parseLegacyStatus(status string) (state, health string, exitCode int) {
+	lower := strings.ToLower(strings.TrimSpace(status))
+
+	switch {
+	case strings.HasPrefix(lower, "up"), strings.HasPrefix(lower, "running"):
+		state = "running"
+	case strings.HasPrefix(lower, "exit"):
+		state = "exited"
+		if m := legacyExitCodePattern.FindStringSubmatch(lower); m != nil {
+			exitCode, _ = strconv.Atoi(m[1])
+		}
+	case strings.HasPrefix(lower, "restarting"):
+		state = "restarting"
+	case strings.HasPrefix(lower, "dead"):
+		state = "dead"
+	case strings.HasPrefix(lower, "paused"):
+		state = "paused"
+	case strings.HasPrefix(lower, "created"):
+		state = "created"
+	default:
+		state = lower
+	}
+
+	switch {
+	case strings.Contains(lower, "(unhealthy)"):
+		health = "unhealthy"
+	case strings.Contains(lower, "(healthy)"):
+		health = "healthy"
+	case strings.Contains(lower, "starting)"):
+		health = "starting"
+	}
+
+	return state, health, exitCode
+}
+
+func (c ComposeContainer) Failed() bool {
+	switch c.State {
+	case "dead", "restarting":
+		return true
+	case "exited":
+		return c.ExitCode != 0
+	}
+	return false
+}
+
+func (c ComposeContainer) String() string {
+	summary := fmt.Sprintf("%s (service: %s) — STATE: %s", c.Name, c.Service, c.State)
+	if c.State == "exited" {
+		summary += fmt.Sprintf(", EXIT CODE: %d", c.ExitCode)
+	}
+	if c.Health != "" {
+		summary += fmt.Sprintf(", HEALTH: %s", c.Health)
+	}
+	if len(c.Ports) > 0 {
+		summary += fmt.Sprintf(", PORTS: %s", strings.Join(c.Ports, ", "))
+	}
+	return summary
+}
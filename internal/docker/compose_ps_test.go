@@ -0,0 +1,166 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseComposePSJSON_NewlineDelimited(t *testing.T) {
+	out := `{"Name":"app-web-1","Service":"web","State":"running","Health":"healthy","ExitCode":0,"Publishers":[{"URL":"0.0.0.0","TargetPort":80,"PublishedPort":8080,"Protocol":"tcp"}]}
+{"Name":"app-db-1","Service":"db","State":"exited","Health":"","ExitCode":1,"Publishers":null}
+`
+	containers, err := parseComposePSJSON(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ComposeContainer{
+		{Name: "app-web-1", Service: "web", State: "running", Health: "healthy", Ports: []string{"0.0.0.0:8080->80/tcp"}},
+		{Name: "app-db-1", Service: "db", State: "exited", ExitCode: 1},
+	}
+	if !reflect.DeepEqual(containers, expected) {
+		t.Errorf("expected %+v, got %+v", expected, containers)
+	}
+}
+
+func TestParseComposePSJSON_Array(t *testing.T) {
+	out := `[{"Name":"app-web-1","Service":"web","State":"running","Health":"starting","ExitCode":0,"Publishers":[{"URL":"","TargetPort":443,"PublishedPort":0,"Protocol":"tcp"}]}]`
+
+	containers, err := parseComposePSJSON(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ComposeContainer{
+		{Name: "app-web-1", Service: "web", State: "running", Health: "starting", Ports: []string{"443/tcp"}},
+	}
+	if !reflect.DeepEqual(containers, expected) {
+		t.Errorf("expected %+v, got %+v", expected, containers)
+	}
+}
+
+func TestParseComposePSJSON_Empty(t *testing.T) {
+	for _, out := range []string{"", "\n", "[]"} {
+		containers, err := parseComposePSJSON(out)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", out, err)
+		}
+		if len(containers) != 0 {
+			t.Errorf("expected no containers for %q, got %+v", out, containers)
+		}
+	}
+}
+
+func TestParseComposePSJSON_Invalid(t *testing.T) {
+	if _, err := parseComposePSJSON("NAME   STATUS\nweb    Up"); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+}
+
+func TestParseComposePSTable_Legacy(t *testing.T) {
+	out := `     Name                   Command               State                 Ports
+-----------------------------------------------------------------------------------------
+myapp_web_1      nginx -g daemon off;             Up (healthy)   0.0.0.0:80->80/tcp, 443/tcp
+myapp_worker_1   python worker.py --queue main    Exit 137
+myapp_cache_1    docker-entrypoint.sh redis ...   Restarting
+`
+	containers := parseComposePSTable(out)
+
+	expected := []ComposeContainer{
+		{Name: "myapp_web_1", State: "running", Health: "healthy", Ports: []string{"0.0.0.0:80->80/tcp", "443/tcp"}},
+		{Name: "myapp_worker_1", State: "exited", ExitCode: 137},
+		{Name: "myapp_cache_1", State: "restarting"},
+	}
+	if !reflect.DeepEqual(containers, expected) {
+		t.Errorf("expected %+v, got %+v", expected, containers)
+	}
+}
+
+func TestParseServiceLabels_HyphenatedProject(t *testing.T) {
+	out := `/my-app_web_1 web
+/my_app-api_server-1 api_server
+/standalone 
+`
+	services := parseServiceLabels(out)
+
+	expected := map[string]string{
+		"my-app_web_1":        "web",
+		"my_app-api_server-1": "api_server",
+	}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("expected %v, got %v", expected, services)
+	}
+}
+
+func TestFormatUnsupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		stderr   string
+		expected bool
+	}{
+		{"compose v2 unknown flag", "unknown flag: --format\nSee 'docker compose ps --help'.", true},
+		{"compose v1 usage", "Show services.\n\nUsage: ps [options] [--] [SERVICE...]\n", true},
+		{"missing compose file", "no configuration file provided: not found", false},
+		{"daemon unreachable", "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?", false},
+		{"no stderr", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatUnsupported(tt.stderr); got != tt.expected {
+				t.Errorf("formatUnsupported(%q) = %v, expected %v", tt.stderr, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseLegacyStatus(t *testing.T) {
+	tests := []struct {
+		status   string
+		state    string
+		health   string
+		exitCode int
+	}{
+		{"Up 3 minutes", "running", "", 0},
+		{"Up 10 seconds (health: starting)", "running", "starting", 0},
+		{"Up (unhealthy)", "running", "unhealthy", 0},
+		{"Exit 2", "exited", "", 2},
+		{"Exited (1) 5 seconds ago", "exited", "", 1},
+		{"Dead", "dead", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			state, health, exitCode := parseLegacyStatus(tt.status)
+			if state != tt.state || health != tt.health || exitCode != tt.exitCode {
+				t.Errorf("parseLegacyStatus(%q) = (%q, %q, %d), expected (%q, %q, %d)",
+					tt.status, state, health, exitCode, tt.state, tt.health, tt.exitCode)
+			}
+		})
+	}
+}
+
+func TestComposeContainerFailed(t *testing.T) {
+	tests := []struct {
+		name      string
+		container ComposeContainer
+		expected  bool
+	}{
+		{"running", ComposeContainer{State: "running"}, false},
+		{"exited cleanly", ComposeContainer{State: "exited", ExitCode: 0}, false},
+		{"exited with error", ComposeContainer{State: "exited", ExitCode: 1}, true},
+		{"restarting", ComposeContainer{State: "restarting"}, true},
+		{"dead", ComposeContainer{State: "dead"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.container.Failed(); got != tt.expected {
+				t.Errorf("Failed() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+)
+
+const healthPollInterval = 2 * time.Second
+
+func waitForHealthy(cli *client.Client, compose, filePath string, services []string, timeout time.Duration) ([]ComposeContainer, error) {
+	logs.Step("\U0001FA7A Waiting for container health checks...")
+	logs.Verbosef("Health check timeout: %s", timeout)
+
+	ctx, cancel := context.WithTimeout(cli.Context(), timeout)
+	defer cancel()
+
+	var pending []ComposeContainer
+
+	for {
+		containers, err := listComposeContainers(ctx, cli, compose, filePath, services)
+		if err != nil {
+			if ctx.Err() != nil {
+				return healthTimedOut(cli, pending, timeout)
+			}
+			return nil, fmt.Errorf("failed to inspect container health: %v", err)
+		}
+
+		var checked, failed []ComposeContainer
+		checked, pending, failed = classifyHealth(containers)
+
+		if len(failed) > 0 {
+			logs.Substepf("\u2022 Health check failed for %d container%s", len(failed), utils.Plural(len(failed)))
+			printContainerHealth(failed)
+			return failed, fmt.Errorf("one or more containers failed their health check")
+		}
+
+		if len(pending) == 0 {
+			if len(checked) == 0 {
+				logs.Info("No containers define a healthcheck")
+			} else {
+				logs.Successf("%d container%s reported healthy", len(checked), utils.Plural(len(checked)))
+			}
+			return nil, nil
+		}
+
+		logs.Verbosef("Waiting on %d container%s to become healthy...", len(pending), utils.Plural(len(pending)))
+
+		select {
+		case <-ctx.Done():
+			return healthTimedOut(cli, pending, timeout)
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// classifyHealth sorts one poll's containers by health check outcome. Containers
+// without a healthcheck are left out; checked holds every one that has one.
+func classifyHealth(containers []ComposeContainer) (checked, pending, failed []ComposeContainer) {
+	for _, c := range containers {
+		if c.Health == "" {
+			continue
+		}
+		checked = append(checked, c)
+
+		switch {
+		case c.State != "running" || c.Health == "unhealthy":
+			failed = append(failed, c)
+		case c.Health != "healthy":
+			pending = append(pending, c)
+		}
+	}
+	return checked, pending, failed
+}
+
+func healthTimedOut(cli *client.Client, pending []ComposeContainer, timeout time.Duration) ([]ComposeContainer, error) {
+	if cli.Context().Err() != nil {
+		return nil, context.Cause(cli.Context())
+	}
+	logs.Substepf("\u2022 Health check timed out for %d container%s", len(pending), utils.Plural(len(pending)))
+	printContainerHealth(pending)
+	return pending, fmt.Errorf("containers did not become healthy within %s", timeout)
+}
+
+func printContainerHealth(containers []ComposeContainer) {
+	for _, c := range containers {
+		fmt.Printf("      \u2192 %s\n", c)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyHealth(t *testing.T) {
+	healthy := ComposeContainer{Name: "app-web-1", State: "running", Health: "healthy"}
+	starting := ComposeContainer{Name: "app-api-1", State: "running", Health: "starting"}
+	unhealthy := ComposeContainer{Name: "app-db-1", State: "running", Health: "unhealthy"}
+	exited := ComposeContainer{Name: "app-worker-1", State: "exited", Health: "starting", ExitCode: 1}
+	noCheck := ComposeContainer{Name: "app-cache-1", State: "running"}
+	noCheckExited := ComposeContainer{Name: "app-migrate-1", State: "exited", ExitCode: 1}
+
+	tests := []struct {
+		name       string
+		containers []ComposeContainer
+		checked    []ComposeContainer
+		pending    []ComposeContainer
+		failed     []ComposeContainer
+	}{
+		{"all healthy", []ComposeContainer{healthy, noCheck}, []ComposeContainer{healthy}, nil, nil},
+		{"still starting", []ComposeContainer{healthy, starting}, []ComposeContainer{healthy, starting}, []ComposeContainer{starting}, nil},
+		{"unhealthy", []ComposeContainer{unhealthy, starting}, []ComposeContainer{unhealthy, starting}, []ComposeContainer{starting}, []ComposeContainer{unhealthy}},
+		{"exited while starting", []ComposeContainer{exited}, []ComposeContainer{exited}, nil, []ComposeContainer{exited}},
+		{"no healthchecks", []ComposeContainer{noCheck, noCheckExited}, nil, nil, nil},
+		{"no containers", nil, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked, pending, failed := classifyHealth(tt.containers)
+			if !reflect.DeepEqual(checked, tt.checked) {
+				t.Errorf("checked = %v, expected %v", checked, tt.checked)
+			}
+			if !reflect.DeepEqual(pending, tt.pending) {
+				t.Errorf("pending = %v, expected %v", pending, tt.pending)
+			}
+			if !reflect.DeepEqual(failed, tt.failed) {
+				t.Errorf("failed = %v, expected %v", failed, tt.failed)
+			}
+		})
+	}
+}
@@ -34,7 +34,7 @@ func EnsureDockerNetwork(cli *client.Client, cfg config.DeployConfig) {
 	logs.Verbosef("Checking if Docker network '%s' exists", network)
 	logs.VerboseCommandf("docker network inspect %s >/dev/null", network)
 
-	existsOut, _, err := cli.RunCommandBuffered(existsCmd)
+	existsOut, _, err := cli.RunIdempotentBuffered(existsCmd)
 	if err != nil {
 		logs.Fatalf("Failed to check Docker network existence: %v", err)
 	}
@@ -47,7 +47,7 @@ func EnsureDockerNetwork(cli *client.Client, cfg config.DeployConfig) {
 		logs.Verbosef("Checking driver of network '%s'", network)
 		logs.VerboseCommandf("%s", driverCmd)
 
-		driverOut, _, err := cli.RunCommandBuffered(driverCmd)
+		driverOut, _, err := cli.RunIdempotentBuffered(driverCmd)
 		if err != nil {
 			logs.Fatalf("Could not verify driver for network '%s': %v", network, err)
 		}
@@ -11,7 +11,7 @@ import (
 func RunDockerPrune(cli *client.Client, cfg config.DeployConfig) {
 	logs.IsVerbose = cfg.Verbose
 
-	pruneType := strings.ToLower(cfg.DockerPrune)
+	pruneType := cfg.DockerPrune
 
 	if pruneType == "" || pruneType == "none" {
 		return
@@ -94,44 +94,34 @@ func runStackDeployment(cli *client.Client, cfg config.DeployConfig) error {
 		docker stack deploy -c "$DEPLOY_FILE" "$STACK" $WITH_AUTH --detach=false
 	`, stackName, cfg.ProjectPath, deployFilePath, cfg.EnvVars, withAuth)
 
-	return cli.RunCommandStreamed(cmd)
+	ctx, cancel := phaseContext(cli.Context(), "stack deploy", cfg.StackDeployTimeout)
+	defer cancel()
+
+	return cli.RunCommandStreamedContext(ctx, cmd)
 }
 
 func validateStackStatus(cli *client.Client, cfg config.DeployConfig, afterDeployFailure bool) error {
 	logs.Step("\U0001F50E Validating stack status...")
 	logs.Verbosef("Validating status of stack '%s'...", cfg.StackName)
 
-	cmd := fmt.Sprintf(`docker service ls --filter "label=com.docker.stack.namespace=%s"`, cfg.StackName)
-	logs.VerboseCommand(cmd)
-
-	output, _, err := cli.RunCommandBuffered(cmd)
+	services, err := listStackServices(cli, cfg.StackName)
 	if err != nil {
 		return fmt.Errorf("error verifying services: %w", err)
 	}
 
-	var failedServices []string
-
-	for line := range strings.SplitSeq(strings.TrimSpace(output), "\n") {
-		fields := strings.Fields(line)
-		if len(fields) < 5 {
-			continue
-		}
-
-		replicas := fields[3]
-		name := fields[1]
-		image := fields[4]
+	var failedServices []ServiceStatus
 
-		if parts := strings.Split(replicas, "/"); len(parts) == 2 && parts[0] != parts[1] {
-			failedServices = append(failedServices,
-				fmt.Sprintf("      \u2192 %s — REPLICAS: %s, IMAGE: %s", name, replicas, image),
-			)
+	for _, svc := range services {
+		if !svc.Converged() {
+			failedServices = append(failedServices, svc)
 		}
 	}
 
 	if len(failedServices) > 0 {
 		logs.Substepf("\u2022 Health check failed for %d service%s", len(failedServices), utils.Plural(len(failedServices)))
-		for _, msg := range failedServices {
-			fmt.Println(msg)
+		for _, svc := range failedServices {
+			fmt.Printf("      \u2192 %s\n", svc)
+			printServiceTasks(cli, svc.Name, cfg.StackTaskHistory)
 		}
 		logs.Errorf("Stack validation failed for '%s'", cfg.StackName)
 		return fmt.Errorf("one or more services failed to start")
@@ -151,6 +141,10 @@ func validateStackStatus(cli *client.Client, cfg config.DeployConfig, afterDeplo
 }
 
 func handleDeploymentFailures(cli *client.Client, cfg config.DeployConfig) {
+	if cli.Context().Err() != nil {
+		logs.Fatalf("Deployment interrupted")
+	}
+
 	if err := validateStackStatus(cli, cfg, true); err == nil {
 		logs.Fatalf("Deployment failed")
 	}
@@ -160,7 +154,7 @@ func handleDeploymentFailures(cli *client.Client, cfg config.DeployConfig) {
 		logs.Step("\U0001F504 Starting rollback...")
 
 		services := getServiceStatus(cli, cfg.StackName)
-		if rollbackStack(cli, services) {
+		if rolledBack, err := rollbackStack(cli, services, nil); err == nil && rolledBack {
 			logs.Fatalf("Deployment failed — rollback succeeded")
 		}
 	}
@@ -168,49 +162,69 @@ func handleDeploymentFailures(cli *client.Client, cfg config.DeployConfig) {
 	logs.Fatalf("Deployment failed")
 }
 
-func getServiceStatus(cli *client.Client, stack string) []string {
-	logs.Verbosef("Fetching service list for rollback in stack '%s'...", stack)
+func RollbackStack(cli *client.Client, cfg config.DeployConfig) error {
+	logs.Step("\U0001F504 Rolling back interrupted stack deployment...")
 
-	cmd := fmt.Sprintf(`docker service ls --filter "label=com.docker.stack.namespace=%s" --format "{{.Name}} {{.Replicas}}"`, stack)
-	logs.VerboseCommand(cmd)
+	services, err := listStackServices(cli, cfg.StackName)
+	if err != nil {
+		return fmt.Errorf("could not retrieve service list: %w", err)
+	}
 
-	output, _, err := cli.RunCommandBuffered(cmd)
+	updating, err := listUpdatingServices(cli, services)
+	if err != nil {
+		logs.Warnf("Could not read service update status: %v", err)
+	}
+
+	rolledBack, err := rollbackStack(cli, services, updating)
+	if err == nil && !rolledBack {
+		logs.Info("No services needed rolling back")
+	}
+	return err
+}
+
+func getServiceStatus(cli *client.Client, stack string) []ServiceStatus {
+	logs.Verbosef("Fetching service list for rollback in stack '%s'...", stack)
+
+	services, err := listStackServices(cli, stack)
 	if err != nil {
 		logs.Warnf("Could not retrieve service list: %v", err)
 		return nil
 	}
 
-	return strings.Split(strings.TrimSpace(output), "\n")
+	return services
 }
 
-func rollbackStack(cli *client.Client, lines []string) bool {
+// rollbackStack rolls back every service that has not converged or is still
+// listed in updating, and reports whether any service was rolled back.
+func rollbackStack(cli *client.Client, services []ServiceStatus, updating map[string]bool) (bool, error) {
 	var rolledBack bool
+	var failed []string
 
-	for _, line := range lines {
-		parts := strings.Fields(line)
-		if len(parts) != 2 {
-			continue
-		}
+	for _, svc := range services {
+		name := svc.Name
 
-		name := parts[0]
-		replicas := parts[1]
-
-		replicaParts := strings.Split(replicas, "/")
-		if len(replicaParts) == 2 && replicaParts[0] != replicaParts[1] {
+		switch {
+		case svc.Converged() && !updating[name]:
+			logs.Verbosef("No rollback needed for %s (replicas: %s)", name, svc.Replicas)
+		case svc.IsJob():
+			logs.Verbosef("Skipping rollback for job service %s (replicas: %s)", name, svc.Replicas)
+		default:
 			logs.Substepf("\U0001F501 Rolling back %s", name)
 			cmd := fmt.Sprintf(`docker service update --rollback "%s"`, name)
 			logs.VerboseCommand(cmd)
 
 			if err := cli.RunCommandStreamed(cmd); err != nil {
 				logs.Warnf("Rollback failed for %s", name)
+				failed = append(failed, name)
 			} else {
 				logs.Successf("Rolled back: %s", name)
 				rolledBack = true
 			}
-		} else {
-			logs.Verbosef("No rollback needed for %s (replicas: %s)", name, replicas)
 		}
 	}
 
-	return rolledBack
+	if len(failed) > 0 {
+		return rolledBack, fmt.Errorf("rollback failed for %s", strings.Join(failed, ", "))
+	}
+	return rolledBack, nil
 }
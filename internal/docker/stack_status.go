@@ -0,0 +1,118 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+)
+
+var replicasPattern = regexp.MustCompile(`^(\d+)/(\d+)(?:\s*\((\d+)/(\d+) completed\))?`)
+
+func listStackServices(cli *client.Client, stack string) ([]ServiceStatus, error) {
+	cmd := fmt.Sprintf(`docker service ls --filter "label=com.docker.stack.namespace=%s" --format '{{json .}}'`, stack)
+	logs.VerboseCommand(cmd)
+
+	stdout, stderr, err := cli.RunIdempotentBuffered(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%v\nDetails: %s", err, strings.TrimSpace(stderr))
+	}
+
+	return parseServiceStatuses(stdout)
+}
+
+func parseServiceStatuses(out string) ([]ServiceStatus, error) {
+	var services []ServiceStatus
+
+	for line := range strings.SplitSeq(strings.TrimSpace(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var entry serviceLSEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse service ls output: %w", err)
+		}
+
+		svc := ServiceStatus{
+			ID:       entry.ID,
+			Name:     entry.Name,
+			Mode:     strings.ReplaceAll(strings.ToLower(strings.TrimSpace(entry.Mode)), " ", "-"),
+			Image:    entry.Image,
+			Ports:    entry.Ports,
+			Replicas: entry.Replicas,
+		}
+
+		if m := replicasPattern.FindStringSubmatch(strings.TrimSpace(entry.Replicas)); m != nil {
+			svc.Parsed = true
+			svc.Running, _ = strconv.Atoi(m[1])
+			svc.Desired, _ = strconv.Atoi(m[2])
+			if m[3] != "" {
+				svc.Completed, _ = strconv.Atoi(m[3])
+				svc.Total, _ = strconv.Atoi(m[4])
+			}
+		}
+
+		services = append(services, svc)
+	}
+
+	return services, nil
+}
+
+func listUpdatingServices(cli *client.Client, services []ServiceStatus) (map[string]bool, error) {
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	var names strings.Builder
+	for _, svc := range services {
+		fmt.Fprintf(&names, ` "%s"`, svc.Name)
+	}
+	cmd := fmt.Sprintf(`docker service inspect --format '{{.Spec.Name}} {{if .UpdateStatus}}{{.UpdateStatus.State}}{{end}}'%s`, names.String())
+	logs.VerboseCommand(cmd)
+
+	stdout, stderr, err := cli.RunIdempotentBuffered(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%v\nDetails: %s", err, strings.TrimSpace(stderr))
+	}
+
+	return parseUpdatingServices(stdout), nil
+}
+
+func parseUpdatingServices(out string) map[string]bool {
+	updating := map[string]bool{}
+
+	for line := range strings.SplitSeq(strings.TrimSpace(out), "\n") {
+		name, state, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch strings.TrimSpace(state) {
+		case "updating", "paused":
+			updating[name] = true
+		}
+	}
+
+	return updating
+}
+
+func (s ServiceStatus) IsJob() bool {
+	return strings.HasSuffix(s.Mode, "-job")
+}
+
+func (s ServiceStatus) Converged() bool {
+	if !s.Parsed {
+		// Replica counts in a format we do not recognise are unknown, not zero.
+		return false
+	}
+	if s.IsJob() && s.Total > 0 {
+		return s.Completed == s.Total
+	}
+	return s.Running == s.Desired
+}
+
+func (s ServiceStatus) String() string {
+	return fmt.Sprintf("%s — MODE: %s, REPLICAS: %s, IMAGE: %s", s.Name, s.Mode, s.Replicas, s.Image)
+}
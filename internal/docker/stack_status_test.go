@@ -0,0 +1,142 @@
+//go:build unit
+// +build unit
+
+package docker
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseServiceStatuses(t *testing.T) {
+	out := `{"ID":"a1","Image":"nginx:latest","Mode":"replicated","Name":"app_web","Ports":"*:8080->80/tcp","Replicas":"2/3"}
+{"ID":"b2","Image":"redis:latest","Mode":"global","Name":"app_cache","Ports":"","Replicas":"1/1"}
+{"ID":"c3","Image":"busybox","Mode":"replicated job","Name":"app_migrate","Ports":"","Replicas":"0/1 (1/1 completed)"}
+{"ID":"d4","Image":"busybox","Mode":"global job","Name":"app_seed","Ports":"","Replicas":"1/1 (0/1 completed)"}
+{"ID":"e5","Image":"nginx:latest","Mode":"replicated","Name":"app_proxy","Ports":"","Replicas":"2/2 (max 1 per node)"}
+{"ID":"f6","Image":"nginx:latest","Mode":"replicated","Name":"app_blank","Ports":"","Replicas":""}
+{"ID":"g7","Image":"nginx:latest","Mode":"replicated","Name":"app_future","Ports":"","Replicas":"ready: 2 of 2"}
+`
+	services, err := parseServiceStatuses(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(services) != 7 {
+		t.Fatalf("expected 7 services, got %d", len(services))
+	}
+
+	tests := []struct {
+		name      string
+		mode      string
+		job       bool
+		converged bool
+	}{
+		{"app_web", "replicated", false, false},
+		{"app_cache", "global", false, true},
+		{"app_migrate", "replicated-job", true, true},
+		{"app_seed", "global-job", true, false},
+		{"app_proxy", "replicated", false, true},
+		{"app_blank", "replicated", false, false},
+		{"app_future", "replicated", false, false},
+	}
+
+	for i, tt := range tests {
+		svc := services[i]
+		if svc.Name != tt.name {
+			t.Errorf("services[%d]: expected name %s, got %s", i, tt.name, svc.Name)
+		}
+		if svc.Mode != tt.mode {
+			t.Errorf("%s: expected mode %s, got %s", tt.name, tt.mode, svc.Mode)
+		}
+		if svc.IsJob() != tt.job {
+			t.Errorf("%s: expected IsJob() to be %v", tt.name, tt.job)
+		}
+		if svc.Converged() != tt.converged {
+			t.Errorf("%s: expected Converged() to be %v (replicas: %s)", tt.name, tt.converged, svc.Replicas)
+		}
+	}
+
+	if services[0].Running != 2 || services[0].Desired != 3 {
+		t.Errorf("expected app_web replicas 2/3, got %d/%d", services[0].Running, services[0].Desired)
+	}
+	if services[2].Completed != 1 || services[2].Total != 1 {
+		t.Errorf("expected app_migrate completions 1/1, got %d/%d", services[2].Completed, services[2].Total)
+	}
+}
+
+func TestParseServiceStatuses_Invalid(t *testing.T) {
+	if _, err := parseServiceStatuses("ID NAME MODE REPLICAS IMAGE"); err == nil {
+		t.Error("expected error for non-JSON output")
+	}
+}
+
+func TestParseServiceTasks(t *testing.T) {
+	out := `{"CurrentState":"Rejected 2 minutes ago","DesiredState":"Shutdown","Error":"no suitable node (scheduling constraints not satisfied on 1 node)","ID":"t1","Image":"nginx","Name":"app_web.1","Node":"","Ports":""}
+{"CurrentState":"Failed 3 minutes ago","DesiredState":"Shutdown","Error":"task: non-zero exit (1)","ID":"t2","Image":"nginx","Name":"\\_ app_web.1","Node":"node-1","Ports":""}
+`
+	tasks, err := parseServiceTasks(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	if tasks[0].Error != "no suitable node (scheduling constraints not satisfied on 1 node)" {
+		t.Errorf("unexpected error for first task: %s", tasks[0].Error)
+	}
+	if tasks[1].Name != "app_web.1" {
+		t.Errorf("expected history prefix to be stripped, got %q", tasks[1].Name)
+	}
+	if tasks[1].Node != "node-1" || tasks[1].Error != "task: non-zero exit (1)" {
+		t.Errorf("unexpected second task: %+v", tasks[1])
+	}
+}
+
+func TestRecentTasks_PerSlot(t *testing.T) {
+	var tasks []ServiceTask
+	for _, slot := range []string{"app_web.1", "app_web.2"} {
+		for i := range 5 {
+			tasks = append(tasks, ServiceTask{ID: fmt.Sprintf("%s-%d", slot, i), Name: slot})
+		}
+	}
+
+	recent := recentTasks(tasks, 2)
+
+	var ids []string
+	for _, task := range recent {
+		ids = append(ids, task.ID)
+	}
+	expected := []string{"app_web.1-0", "app_web.1-1", "app_web.2-0", "app_web.2-1"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+}
+
+func TestParseUpdatingServices(t *testing.T) {
+	out := `app_web updating
+app_cache completed
+app_api paused
+app_worker
+app_proxy rollback_started
+`
+	updating := parseUpdatingServices(out)
+
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"app_web", true},
+		{"app_cache", false},
+		{"app_api", true},
+		{"app_worker", false},
+		{"app_proxy", false},
+	}
+
+	for _, tt := range tests {
+		if updating[tt.name] != tt.expected {
+			t.Errorf("%s: expected updating=%v, got %v", tt.name, tt.expected, updating[tt.name])
+		}
+	}
+}
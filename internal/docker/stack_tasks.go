@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+)
+
+func listServiceTasks(cli *client.Client, service string) ([]ServiceTask, error) {
+	cmd := fmt.Sprintf(`docker service ps --no-trunc --format '{{json .}}' "%s"`, service)
+	logs.VerboseCommand(cmd)
+
+	stdout, stderr, err := cli.RunIdempotentBuffered(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("%v\nDetails: %s", err, strings.TrimSpace(stderr))
+	}
+
+	return parseServiceTasks(stdout)
+}
+
+// recentTasks keeps the newest tasks of every slot. docker service ps lists
+// each slot's tasks together, most recent first.
+func recentTasks(tasks []ServiceTask, perSlot int) []ServiceTask {
+	var recent []ServiceTask
+	counts := map[string]int{}
+	for _, task := range tasks {
+		if counts[task.Name] >= perSlot {
+			continue
+		}
+		counts[task.Name]++
+		recent = append(recent, task)
+	}
+	return recent
+}
+
+func parseServiceTasks(out string) ([]ServiceTask, error) {
+	var tasks []ServiceTask
+
+	for line := range strings.SplitSeq(strings.TrimSpace(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var task ServiceTask
+		if err := json.Unmarshal([]byte(line), &task); err != nil {
+			return nil, fmt.Errorf("failed to parse service ps output: %w", err)
+		}
+		task.Name = strings.TrimSpace(strings.TrimPrefix(task.Name, `\_`))
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+func printServiceTasks(cli *client.Client, service string, perSlot int) {
+	tasks, err := listServiceTasks(cli, service)
+	if err != nil {
+		logs.Warnf("Could not retrieve tasks for %s: %v", service, err)
+		return
+	}
+	tasks = recentTasks(tasks, perSlot)
+
+	if len(tasks) == 0 {
+		fmt.Printf("         \u21B3 %sno tasks found%s\n", logs.GrayColor, logs.ResetColor)
+		return
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("         \u21B3 %s\n", task)
+	}
+}
+
+func (t ServiceTask) String() string {
+	node := t.Node
+	if node == "" {
+		node = "unassigned"
+	}
+
+	summary := fmt.Sprintf("%s on %s — %s (desired: %s)", t.Name, node, t.CurrentState, t.DesiredState)
+	if t.Error != "" {
+		summary += fmt.Sprintf(" %serror: %s%s", logs.RedColor, t.Error, logs.ResetColor)
+	}
+	return summary
+}
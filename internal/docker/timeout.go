@@ -0,0 +1,26 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+)
+
+func phaseContext(parent context.Context, phase, timeout string) (context.Context, context.CancelFunc) {
+	if timeout == "" {
+		return context.WithCancel(parent)
+	}
+
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		logs.Fatalf("Invalid %s timeout '%s': %v", phase, timeout, err)
+	}
+	if d <= 0 {
+		return context.WithCancel(parent)
+	}
+
+	logs.Verbosef("Timeout for %s: %s", phase, d)
+	return context.WithTimeoutCause(parent, d, fmt.Errorf("%s timed out after %s", phase, d))
+}
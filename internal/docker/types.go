@@ -0,0 +1,58 @@
+package docker
+
+type ComposeContainer struct {
+	Name     string
+	Service  string
+	State    string
+	Health   string
+	ExitCode int
+	Ports    []string
+}
+
+type composePSEntry struct {
+	Name       string             `json:"Name"`
+	Service    string             `json:"Service"`
+	State      string             `json:"State"`
+	Health     string             `json:"Health"`
+	ExitCode   int                `json:"ExitCode"`
+	Publishers []composePublisher `json:"Publishers"`
+}
+
+type composePublisher struct {
+	URL           string `json:"URL"`
+	TargetPort    int    `json:"TargetPort"`
+	PublishedPort int    `json:"PublishedPort"`
+	Protocol      string `json:"Protocol"`
+}
+
+type ServiceStatus struct {
+	ID        string
+	Name      string
+	Mode      string
+	Image     string
+	Ports     string
+	Replicas  string
+	Running   int
+	Desired   int
+	Completed int
+	Total     int
+	Parsed    bool
+}
+
+type serviceLSEntry struct {
+	ID       string `json:"ID"`
+	Name     string `json:"Name"`
+	Mode     string `json:"Mode"`
+	Image    string `json:"Image"`
+	Ports    string `json:"Ports"`
+	Replicas string `json:"Replicas"`
+}
+
+type ServiceTask struct {
+	ID           string `json:"ID"`
+	Name         string `json:"Name"`
+	Node         string `json:"Node"`
+	DesiredState string `json:"DesiredState"`
+	CurrentState string `json:"CurrentState"`
+	Error        string `json:"Error"`
+}
@@ -27,7 +27,7 @@ func BackupDeploymentFiles(cli *client.Client, cfg config.DeployConfig) {
 	logs.Verbosef("Checking for deploy file in project path: %s", deployFileName)
 	logs.VerboseCommandf("%s", checkDeployFileCmd)
 
-	if _, _, err := cli.RunCommandBuffered(checkDeployFileCmd); err != nil {
+	if _, _, err := cli.RunIdempotentBuffered(checkDeployFileCmd); err != nil {
 		logs.Warnf("Deploy file not found, skipping backup: %s", deployFilePath)
 		return
 	}
@@ -39,14 +39,14 @@ func BackupDeploymentFiles(cli *client.Client, cfg config.DeployConfig) {
 	logs.Verbosef("Backup directory: %s", backupDir)
 
 	mkdirCmd := fmt.Sprintf(`mkdir -p "%s"`, backupDir)
-	if _, stderr, err := cli.RunCommandBuffered(mkdirCmd); err != nil {
+	if _, stderr, err := cli.RunIdempotentBuffered(mkdirCmd); err != nil {
 		logs.Fatalf("Failed to create backup directory: %v\nDetails: %s", err, stderr)
 	}
 
 	backupCmd := fmt.Sprintf(`rsync -a --exclude "%s" "%s/" "%s/"`, path.Base(backupDir), cfg.ProjectPath, backupDir)
 	logs.VerboseCommandf("%s", backupCmd)
 
-	if _, stderr, err := cli.RunCommandBuffered(backupCmd); err != nil {
+	if _, stderr, err := cli.RunIdempotentBuffered(backupCmd); err != nil {
 		logs.Fatalf("Failed to back up project directory: %v\nDetails: %s", err, stderr)
 	} else {
 		logs.Successf("Project directory backed up successfully at: %s", backupDir)
@@ -60,7 +60,7 @@ func RestoreBackup(cli *client.Client, projectPath string) error {
 	findBackupCmd := `ls -td .backup_* 2>/dev/null | head -n 1`
 	logs.VerboseCommandf("%s", findBackupCmd)
 
-	backupDir, _, err := cli.RunCommandBuffered(fmt.Sprintf(`cd "%s" && %s`, projectPath, findBackupCmd))
+	backupDir, _, err := cli.RunIdempotentBuffered(fmt.Sprintf(`cd "%s" && %s`, projectPath, findBackupCmd))
 	backupDir = strings.TrimSpace(backupDir)
 	if err != nil || backupDir == "" {
 		msg := fmt.Sprintf("no backup found in %s", projectPath)
@@ -72,7 +72,7 @@ func RestoreBackup(cli *client.Client, projectPath string) error {
 	restoreCmd := fmt.Sprintf(`cp -r "%s"/* "%s"/`, path.Join(projectPath, backupDir), projectPath)
 	logs.VerboseCommandf("%s", restoreCmd)
 
-	if _, stderr, err := cli.RunCommandBuffered(restoreCmd); err != nil {
+	if _, stderr, err := cli.RunIdempotentBuffered(restoreCmd); err != nil {
 		msg := fmt.Sprintf("failed to restore backup: %s", stderr)
 		logs.Error(msg)
 		return fmt.Errorf("%s", msg)
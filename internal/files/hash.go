@@ -0,0 +1,88 @@
+package files
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+)
+
+const noChecksum = "-"
+
+func localChecksum(localPath string) (string, int64, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// remoteChecksums returns the size and SHA-256 of each path, in the same
+// order, using a single remote command. Hash is noChecksum when the server
+// has no sha256sum.
+func remoteChecksums(cli *client.Client, paths []string) ([]remoteFile, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	stdout, stderr, err := cli.RunIdempotentBuffered(checksumCommand(paths))
+	if err != nil {
+		if msg := strings.TrimSpace(stderr); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, msg)
+		}
+		return nil, err
+	}
+	return parseChecksums(stdout, len(paths))
+}
+
+func checksumCommand(paths []string) string {
+	return fmt.Sprintf(`if command -v sha256sum >/dev/null; then sum() { sha256sum < "$1" | cut -d ' ' -f 1; }; else sum() { echo %s; }; fi
+for f in %s; do
+	if [ -f "$f" ]; then echo "$(wc -c < "$f") $(sum "$f")"; else echo missing; fi
+done`, noChecksum, quoteAll(paths))
+}
+
+func quoteAll(paths []string) string {
+	quoted := make([]string, len(paths))
+	for i, p := range paths {
+		quoted[i] = utils.ShellQuote(p)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func parseChecksums(output string, count int) ([]remoteFile, error) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) != count {
+		return nil, fmt.Errorf("expected %d checksum line%s, got %d", count, utils.Plural(count), len(lines))
+	}
+
+	files := make([]remoteFile, count)
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 1 && fields[0] == "missing" {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected checksum line %q", line)
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected checksum line %q", line)
+		}
+		files[i] = remoteFile{Exists: true, Size: size, Hash: strings.ToLower(fields[1])}
+	}
+	return files, nil
+}
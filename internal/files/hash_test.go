@@ -0,0 +1,91 @@
+//go:build unit
+// +build unit
+
+package files
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	helloHash = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	emptyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+func TestLocalChecksum(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(p, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, size, err := localChecksum(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hash != helloHash {
+		t.Errorf("expected %s, got %s", helloHash, hash)
+	}
+	if size != 5 {
+		t.Errorf("expected size 5, got %d", size)
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	output := strings.Join([]string{
+		"5 " + helloHash,
+		"missing",
+		"      0 " + strings.ToUpper(emptyHash),
+		"12 -",
+	}, "\n") + "\n"
+
+	files, err := parseChecksums(output, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []remoteFile{
+		{Exists: true, Size: 5, Hash: helloHash},
+		{},
+		{Exists: true, Size: 0, Hash: emptyHash},
+		{Exists: true, Size: 12, Hash: noChecksum},
+	}
+	for i, want := range expected {
+		if files[i] != want {
+			t.Errorf("line %d: expected %+v, got %+v", i, want, files[i])
+		}
+	}
+}
+
+func TestParseChecksums_BadOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		count  int
+	}{
+		{"too few lines", "5 " + helloHash + "\n", 2},
+		{"bad size", "five " + helloHash + "\n", 1},
+		{"extra fields", "5 " + helloHash + " -\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseChecksums(tt.output, tt.count); err == nil {
+				t.Errorf("expected error for %q", tt.output)
+			}
+		})
+	}
+}
+
+func TestChecksumCommand_QuotesPaths(t *testing.T) {
+	cmd := checksumCommand([]string{"/srv/app/it's.conf", "/srv/app/$HOME.env"})
+
+	if !strings.Contains(cmd, `'/srv/app/it'\''s.conf'`) {
+		t.Errorf("expected single quote to be escaped, got %s", cmd)
+	}
+	if !strings.Contains(cmd, `'/srv/app/$HOME.env'`) {
+		t.Errorf("expected variables to stay unexpanded, got %s", cmd)
+	}
+}
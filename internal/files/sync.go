@@ -0,0 +1,91 @@
+package files
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/config"
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+)
+
+const manifestName = ".deploy-manifest"
+
+func RemoveStaleFiles(cli *client.Client, cfg config.DeployConfig, files []UploadedFile) {
+	logs.IsVerbose = cfg.Verbose
+
+	if !cfg.SyncDelete {
+		return
+	}
+
+	logs.Step("\U0001F5D1 Removing stale files...")
+
+	root := path.Clean(cfg.ProjectPath)
+	manifestPath := path.Join(root, manifestName)
+
+	logs.Verbosef("Reading manifest: %s", manifestPath)
+	previous, stderr, err := cli.RunIdempotentBuffered(fmt.Sprintf("cat %s 2>/dev/null || true", utils.ShellQuote(manifestPath)))
+	if err != nil {
+		logs.Fatalf("Failed to read manifest: %v\nDetails: %s", err, stderr)
+	}
+
+	current := make([]string, len(files))
+	for i, file := range files {
+		current[i] = path.Clean(file.RemotePath)
+	}
+
+	stale := staleFiles(root, parseManifest(previous), current)
+	if len(stale) == 0 {
+		logs.Success("No stale files to remove")
+	} else {
+		for _, file := range stale {
+			logs.Substepf("\u2022 %s", file)
+		}
+		removeCmd := fmt.Sprintf("rm -f -- %s", quoteAll(stale))
+		logs.VerboseCommandf("%s", removeCmd)
+		if _, stderr, err := cli.RunIdempotentBuffered(removeCmd); err != nil {
+			logs.Fatalf("Failed to remove stale files: %v\nDetails: %s", err, stderr)
+		}
+		logs.Successf("%d stale file%s removed", len(stale), utils.Plural(len(stale)))
+	}
+
+	writeCmd := fmt.Sprintf("printf '%%s\\n' %s > %s && mv -f %s %s",
+		quoteAll(current), utils.ShellQuote(manifestPath+".tmp"), utils.ShellQuote(manifestPath+".tmp"), utils.ShellQuote(manifestPath))
+	if _, stderr, err := cli.RunIdempotentBuffered(writeCmd); err != nil {
+		logs.Fatalf("Failed to write manifest: %v\nDetails: %s", err, stderr)
+	}
+	logs.Verbosef("Manifest updated with %d file%s", len(current), utils.Plural(len(current)))
+}
+
+func parseManifest(content string) []string {
+	var paths []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, path.Clean(line))
+		}
+	}
+	return paths
+}
+
+// staleFiles lists paths from the previous manifest that are no longer
+// uploaded. Anything outside the project path, the manifest itself and
+// backups are never considered stale.
+func staleFiles(root string, previous, current []string) []string {
+	var stale []string
+	for _, p := range previous {
+		if slices.Contains(current, p) || slices.Contains(stale, p) {
+			continue
+		}
+		rel, ok := strings.CutPrefix(p, root+"/")
+		if !ok || rel == manifestName || strings.HasPrefix(rel, ".backup_") {
+			continue
+		}
+		stale = append(stale, p)
+	}
+	sort.Strings(stale)
+	return stale
+}
@@ -0,0 +1,37 @@
+//go:build unit
+// +build unit
+
+package files
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStaleFiles(t *testing.T) {
+	previous := parseManifest(`/srv/app/docker-compose.yml
+/srv/app/conf/old.conf
+/srv/app/conf/keep.conf
+
+/srv/app/conf/old.conf
+/srv/app/.deploy-manifest
+/srv/app/.backup_20240101_120000/docker-compose.yml
+/srv/application/other.conf
+/etc/nginx/nginx.conf
+/srv/app/../other/secret
+`)
+	current := []string{"/srv/app/docker-compose.yml", "/srv/app/conf/keep.conf"}
+
+	stale := staleFiles("/srv/app", previous, current)
+
+	expected := []string{"/srv/app/conf/old.conf"}
+	if !reflect.DeepEqual(stale, expected) {
+		t.Errorf("expected %v, got %v", expected, stale)
+	}
+}
+
+func TestStaleFiles_NoManifest(t *testing.T) {
+	if stale := staleFiles("/srv/app", parseManifest(""), []string{"/srv/app/docker-compose.yml"}); len(stale) != 0 {
+		t.Errorf("expected no stale files on first run, got %v", stale)
+	}
+}
@@ -0,0 +1,137 @@
+package files
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/scp"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/sftp"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/tarstream"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+)
+
+func openUploaders(cli *client.Client, method, root string, workers int) ([]uploader, string) {
+	first, method, err := newUploader(cli, method, root)
+	if err != nil {
+		logs.Fatalf("Unable to start file transfer: %v", err)
+	}
+
+	// Each worker needs its own session; stop adding workers once the server
+	// refuses more (OpenSSH allows 10 per connection by default).
+	ups := []uploader{first}
+	if method == "tar" {
+		// Everything goes through a single archive stream.
+		workers = 1
+	}
+	for len(ups) < workers {
+		up, err := openUploader(cli, method, root)
+		if err != nil {
+			logs.Verbosef("Could not open upload session %d: %v", len(ups)+1, err)
+			logs.Warnf("Server accepted %d of %d upload sessions – continuing with %d worker%s", len(ups), workers, len(ups), utils.Plural(len(ups)))
+			break
+		}
+		ups = append(ups, up)
+	}
+
+	return ups, method
+}
+
+func newUploader(cli *client.Client, method, root string) (uploader, string, error) {
+	if method == "tar" {
+		up, err := tarstream.NewUploader(cli, root)
+		return up, "tar", err
+	}
+	if method != "scp" {
+		up, err := sftp.NewUploader(cli)
+		if err == nil {
+			return up, "sftp", nil
+		}
+		logs.Warnf("%v – falling back to SCP", err)
+	}
+
+	up, err := scp.NewUploader(cli, root)
+	return up, "scp", err
+}
+
+func openUploader(cli *client.Client, method, root string) (uploader, error) {
+	switch method {
+	case "sftp":
+		return sftp.NewUploader(cli)
+	case "tar":
+		return tarstream.NewUploader(cli, root)
+	}
+	return scp.NewUploader(cli, root)
+}
+
+// uploadPlanned shares the planned items between the uploaders and reports each
+// result in planned order, holding back lines for files that finish early.
+// Files sent as part of a batch are only reported once the batch is closed.
+func uploadPlanned(cli *client.Client, ups []uploader, planned []UploadItem) ([]error, error) {
+	errs := make([]error, len(planned))
+	var closeErrs []error
+	done := make([]bool, len(planned))
+	next := 0
+
+	var mu sync.Mutex
+	report := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs[i], done[i] = err, true
+		for ; next < len(planned) && done[next]; next++ {
+			item := planned[next]
+			if errs[next] != nil {
+				logs.Errorf("Failed to upload '%s': %v", item.Source, errs[next])
+				continue
+			}
+			logs.Verbosef("Uploaded '%s' to '%s'", item.Source, item.Destination)
+			logs.Successf("%s uploaded", filepath.Base(item.Source))
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for _, up := range ups {
+		wg.Add(1)
+		go func(up uploader) {
+			defer wg.Done()
+			batch, _ := up.(batchUploader)
+			var batched []int
+			for i := range jobs {
+				err := up.Upload(planned[i].Source, planned[i].Destination)
+				if err == nil && batch != nil && batch.Batched(planned[i].Destination) {
+					batched = append(batched, i)
+					continue
+				}
+				report(i, err)
+			}
+
+			err := up.Close()
+			if len(batched) > 0 {
+				// The batch lands or fails as a whole.
+				for _, i := range batched {
+					report(i, err)
+				}
+				return
+			}
+			if err != nil {
+				mu.Lock()
+				closeErrs = append(closeErrs, err)
+				mu.Unlock()
+			}
+		}(up)
+	}
+
+	for i := range planned {
+		if cli.Context().Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errs, errors.Join(closeErrs...)
+}
@@ -0,0 +1,158 @@
+//go:build unit
+// +build unit
+
+package files
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+)
+
+type fakeUploader struct {
+	release  map[string]chan error
+	finished chan<- string
+}
+
+func (u *fakeUploader) Upload(localPath, remotePath string) error {
+	err := <-u.release[localPath]
+	u.finished <- localPath
+	return err
+}
+
+func (u *fakeUploader) Close() error {
+	return nil
+}
+
+func TestUploadPlanned_OutOfOrder(t *testing.T) {
+	planned := []UploadItem{
+		{Source: "a.conf", Destination: "/srv/app/a.conf"},
+		{Source: "b.conf", Destination: "/srv/app/b.conf"},
+		{Source: "c.conf", Destination: "/srv/app/c.conf"},
+	}
+	release := map[string]chan error{}
+	for _, item := range planned {
+		release[item.Source] = make(chan error, 1)
+	}
+	finished := make(chan string)
+
+	ups := make([]uploader, len(planned))
+	for i := range ups {
+		ups[i] = &fakeUploader{release: release, finished: finished}
+	}
+
+	uploadErr := errors.New("permission denied")
+	var errs []error
+	var err error
+	output := captureStdout(t, func() {
+		done := make(chan struct{})
+		go func() {
+			errs, err = uploadPlanned(&client.Client{}, ups, planned)
+			close(done)
+		}()
+
+		// Finish the files last to first so every result but the final one
+		// arrives before the file planned ahead of it.
+		for _, name := range []string{"c.conf", "b.conf", "a.conf"} {
+			if name == "b.conf" {
+				release[name] <- uploadErr
+			} else {
+				release[name] <- nil
+			}
+			if got := <-finished; got != name {
+				t.Errorf("expected %s to finish, got %s", name, got)
+			}
+		}
+		<-done
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(errs) != 3 || errs[0] != nil || !errors.Is(errs[1], uploadErr) || errs[2] != nil {
+		t.Errorf("expected errors [nil %v nil], got %v", uploadErr, errs)
+	}
+
+	lines := []string{"a.conf uploaded", "Failed to upload 'b.conf': permission denied", "c.conf uploaded"}
+	last := -1
+	for _, line := range lines {
+		i := strings.Index(output, line)
+		if i < 0 {
+			t.Fatalf("expected %q in output:\n%s", line, output)
+		}
+		if i < last {
+			t.Errorf("expected results in planned order, got:\n%s", output)
+		}
+		last = i
+	}
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+
+	fn()
+	w.Close()
+	return <-out
+}
+
+type fakeBatchUploader struct {
+	closeErr error
+}
+
+func (u *fakeBatchUploader) Upload(localPath, remotePath string) error {
+	return nil
+}
+
+func (u *fakeBatchUploader) Batched(remotePath string) bool {
+	return strings.HasPrefix(remotePath, "/srv/app/")
+}
+
+func (u *fakeBatchUploader) Close() error {
+	return u.closeErr
+}
+
+func TestUploadPlanned_BatchFailure(t *testing.T) {
+	planned := []UploadItem{
+		{Source: "a.conf", Destination: "/srv/app/a.conf"},
+		{Source: "b.conf", Destination: "/etc/b.conf"},
+		{Source: "c.conf", Destination: "/srv/app/c.conf"},
+	}
+	closeErr := errors.New("tar extraction failed: No space left on device")
+
+	var errs []error
+	var err error
+	output := captureStdout(t, func() {
+		errs, err = uploadPlanned(&client.Client{}, []uploader{&fakeBatchUploader{closeErr: closeErr}}, planned)
+	})
+
+	if err != nil {
+		t.Fatalf("expected the close error to be reported per file, got %v", err)
+	}
+	if len(errs) != 3 || !errors.Is(errs[0], closeErr) || errs[1] != nil || !errors.Is(errs[2], closeErr) {
+		t.Errorf("expected errors [%v nil %v], got %v", closeErr, closeErr, errs)
+	}
+	if strings.Contains(output, "a.conf uploaded") || strings.Contains(output, "c.conf uploaded") {
+		t.Errorf("expected no batched file reported as uploaded, got:\n%s", output)
+	}
+	if !strings.Contains(output, "b.conf uploaded") {
+		t.Errorf("expected the file sent outside the batch to be reported, got:\n%s", output)
+	}
+}
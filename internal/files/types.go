@@ -1,13 +1,36 @@
 package files
 
+type uploader interface {
+	Upload(localPath, remotePath string) error
+	Close() error
+}
+
+// batchUploader is an uploader that only puts some files in place once it is
+// closed, such as the tar stream; Batched reports which ones.
+type batchUploader interface {
+	uploader
+	Batched(remotePath string) bool
+}
+
 type UploadItem struct {
 	Source      string
 	Destination string
 	Note        string
 	NoteColor   string
+	Hash        string
+	Size        int64
+	Unchanged   bool
 }
 
 type UploadedFile struct {
 	File       string
 	RemotePath string
+	Hash       string
+	Size       int64
+}
+
+type remoteFile struct {
+	Exists bool
+	Size   int64
+	Hash   string
 }
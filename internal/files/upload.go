@@ -1,17 +1,19 @@
 package files
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/alcharra/docker-deploy-action-go/config"
 	"github.com/alcharra/docker-deploy-action-go/internal/logs"
 	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
-	"github.com/alcharra/docker-deploy-action-go/internal/ssh/scp"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
 )
 
 func UploadFiles(cli *client.Client, cfg config.DeployConfig) []UploadedFile {
@@ -172,6 +174,32 @@ func UploadFiles(cli *client.Client, cfg config.DeployConfig) []UploadedFile {
 		return planned[i].Source < planned[j].Source
 	})
 
+	if cfg.EnvVars != "" && slices.ContainsFunc(planned, func(item UploadItem) bool { return item.Source == ".env" }) {
+		logs.Verbose("Creating temporary .env file with inline variables")
+		if err := os.WriteFile(".env", []byte(cfg.EnvVars), 0644); err != nil {
+			logs.Fatalf("Failed to create .env file: %v", err)
+		}
+		defer os.Remove(".env")
+	}
+
+	for i := range planned {
+		hash, size, err := localChecksum(planned[i].Source)
+		if err != nil {
+			logs.Fatalf("Unable to compute checksum of '%s': %v", planned[i].Source, err)
+		}
+		planned[i].Hash, planned[i].Size = hash, size
+	}
+
+	var pending []UploadItem
+	if cfg.SkipUnchanged {
+		markUnchanged(cli, planned)
+	}
+	for _, item := range planned {
+		if !item.Unchanged {
+			pending = append(pending, item)
+		}
+	}
+
 	maxSrcLen, maxDstLen := 0, 0
 	for _, item := range planned {
 		if len(item.Source) > maxSrcLen {
@@ -193,30 +221,79 @@ func UploadFiles(cli *client.Client, cfg config.DeployConfig) []UploadedFile {
 		}
 	}
 	logs.Break()
-	logs.Successf("%d files prepared for upload", len(planned))
+	logs.Successf("%d files prepared for upload", len(pending))
+	if skipped := len(planned) - len(pending); skipped > 0 {
+		logs.Successf("%d unchanged file%s skipped", skipped, utils.Plural(skipped))
+	}
 	logs.Warnf("%d flattening conflicts", flattenConflicts)
 
 	logs.Step("\U0001F4E6 Uploading files...")
-	for _, item := range planned {
-		if item.Source == ".env" && cfg.EnvVars != "" {
-			logs.Verbose("Creating temporary .env file with inline variables")
-			if err := os.WriteFile(".env", []byte(cfg.EnvVars), 0644); err != nil {
-				logs.Fatalf("Failed to create .env file: %v", err)
-			}
-			defer os.Remove(".env")
-		}
 
-		logs.Verbosef("Uploading '%s' to '%s'", item.Source, item.Destination)
-		if err := scp.UploadFileSCP(cli, item.Source, item.Destination); err != nil {
-			logs.Fatalf("Failed to upload '%s': %v", item.Source, err)
+	var errs []error
+	if len(pending) == 0 {
+		logs.Success("All files are unchanged – nothing to upload")
+	} else {
+		ups, method := openUploaders(cli, cfg.TransferMethod, cfg.ProjectPath, min(cfg.UploadConcurrency, len(pending)))
+		logs.Verbosef("Transfer method: %s (%d parallel session%s)", method, len(ups), utils.Plural(len(ups)))
+
+		var err error
+		errs, err = uploadPlanned(cli, ups, pending)
+		if cli.Context().Err() != nil {
+			logs.Fatalf("Upload interrupted: %v", context.Cause(cli.Context()))
+		}
+		if err != nil {
+			logs.Fatalf("Failed to complete %s transfer: %v", method, err)
 		}
-		logs.Successf("%s uploaded", filepath.Base(item.Source))
+	}
 
+	var failed, next int
+	for _, item := range planned {
+		if !item.Unchanged {
+			err := errs[next]
+			next++
+			if err != nil {
+				failed++
+				continue
+			}
+		}
 		uploaded = append(uploaded, UploadedFile{
 			File:       item.Source,
 			RemotePath: item.Destination,
+			Hash:       item.Hash,
+			Size:       item.Size,
 		})
 	}
 
+	if failed > 0 {
+		logs.Fatalf("%d of %d file%s failed to upload", failed, len(pending), utils.Plural(len(pending)))
+	}
+
 	return uploaded
 }
+
+func markUnchanged(cli *client.Client, planned []UploadItem) {
+	destinations := make([]string, len(planned))
+	for i, item := range planned {
+		destinations[i] = item.Destination
+	}
+
+	logs.Verbosef("Fetching remote checksums for %d file%s", len(destinations), utils.Plural(len(destinations)))
+	remote, err := remoteChecksums(cli, destinations)
+	if err != nil {
+		logs.Warnf("Unable to read remote checksums – uploading every file: %v", err)
+		return
+	}
+
+	if slices.ContainsFunc(remote, func(file remoteFile) bool { return file.Hash == noChecksum }) {
+		logs.Warn("sha256sum is not available on the server – uploading every file")
+		return
+	}
+
+	for i, file := range remote {
+		if file.Exists && file.Size == planned[i].Size && file.Hash == planned[i].Hash {
+			planned[i].Unchanged = true
+			planned[i].Note = "(unchanged)"
+			planned[i].NoteColor = logs.GreenColor
+		}
+	}
+}
@@ -1,45 +1,55 @@
 package files
 
 import (
-	"fmt"
 	"path/filepath"
-	"strings"
 
 	"github.com/alcharra/docker-deploy-action-go/config"
 	"github.com/alcharra/docker-deploy-action-go/internal/logs"
 	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
 )
 
 func CheckFilesExistRemote(cli *client.Client, cfg config.DeployConfig, files []UploadedFile) {
 	logs.IsVerbose = cfg.Verbose
 	logs.Step("🧪 Verifying uploaded files...")
 
-	for _, file := range files {
-		remotePath := filepath.ToSlash(file.RemotePath)
-
-		logs.Verbosef("Checking if remote file exists: %s", remotePath)
-		logs.VerboseCommandf("stat %s", remotePath)
-
-		cmd := fmt.Sprintf(`
-			if stat "%s" >/dev/null 2>&1; then
-				echo "OK"
-			else
-				echo "MISSING"
-			fi
-		`, remotePath)
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = filepath.ToSlash(file.RemotePath)
+	}
 
-		stdout, stderr, err := cli.RunCommandBuffered(cmd)
-		if err != nil {
-			logs.Fatalf("Unable to verify remote file '%s': %v\nDetails: %s", remotePath, err, stderr)
-		}
+	logs.Verbosef("Comparing size and checksum of %d remote file%s", len(paths), utils.Plural(len(paths)))
+	remote, err := remoteChecksums(cli, paths)
+	if err != nil {
+		logs.Fatalf("Unable to verify uploaded files: %v", err)
+	}
 
-		switch strings.TrimSpace(stdout) {
-		case "OK":
-			logs.Success(remotePath)
-		case "MISSING":
-			logs.Fatalf("File missing after upload: %s", remotePath)
+	var failed int
+	var sizeOnly bool
+	for i, file := range files {
+		got := remote[i]
+		switch {
+		case !got.Exists:
+			logs.Errorf("File missing after upload: %s", paths[i])
+		case got.Size != file.Size:
+			logs.Errorf("Size mismatch for %s: local %d bytes, remote %d bytes", paths[i], file.Size, got.Size)
+		case got.Hash == noChecksum:
+			sizeOnly = true
+			logs.Success(paths[i])
+			continue
+		case got.Hash != file.Hash:
+			logs.Errorf("Checksum mismatch for %s: local %s, remote %s", paths[i], file.Hash, got.Hash)
 		default:
-			logs.Fatalf("Unexpected verification response for: %s", remotePath)
+			logs.Success(paths[i])
+			continue
 		}
+		failed++
+	}
+
+	if sizeOnly {
+		logs.Warn("sha256sum is not available on the server – only file sizes were compared")
+	}
+	if failed > 0 {
+		logs.Fatalf("%d of %d file%s failed verification", failed, len(files), utils.Plural(len(files)))
 	}
 }
@@ -1,12 +1,28 @@
 package logs
 
 import (
+	"crypto/rand"
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 )
 
 var IsVerbose bool
 
+// NestedEnv is set by the multi-host deploy on each host process, whose output
+// it already wraps in a group. GitHub does not support nested groups, so
+// groups are printed as plain headings instead.
+const NestedEnv = "DOCKER_DEPLOY_NESTED"
+
+var nested = os.Getenv(NestedEnv) != ""
+
+var (
+	halted     atomic.Bool
+	parkOnce   sync.Once
+	parkedChan = make(chan struct{})
+)
+
 func Step(title string) {
 	fmt.Println()
 	fmt.Println(title)
@@ -84,16 +100,77 @@ func VerboseCommandf(format string, args ...interface{}) {
 func Fatal(msg string) {
 	fmt.Println()
 	fmt.Printf("\U0000274C %s\n", msg)
-	os.Exit(1)
+	exit()
 }
 
 func Fatalf(format string, args ...interface{}) {
 	fmt.Println()
 	msg := fmt.Sprintf("\U0000274C "+format+"\n", args...)
 	fmt.Print(msg)
+	exit()
+}
+
+func Halt() {
+	halted.Store(true)
+}
+
+func Parked() <-chan struct{} {
+	return parkedChan
+}
+
+func Park() {
+	parkOnce.Do(func() { close(parkedChan) })
+	select {}
+}
+
+func exit() {
+	if halted.Load() {
+		// The interrupt handler owns shutdown, so park rather than exit
+		// underneath it.
+		Park()
+	}
 	os.Exit(1)
 }
 
+func Group(title string) {
+	if nested {
+		fmt.Println(title)
+		return
+	}
+	fmt.Printf("::group::%s\n", title)
+}
+
+func EndGroup() {
+	if nested {
+		return
+	}
+	fmt.Println("::endgroup::")
+}
+
+// Untrusted prints external output such as container logs with workflow
+// commands disabled, so lines like "::add-mask::" are shown rather than run.
+func Untrusted(output string) {
+	token := rand.Text()
+	fmt.Printf("::stop-commands::%s\n", token)
+	fmt.Println(output)
+	fmt.Printf("::%s::\n", token)
+}
+
+func Summary(markdown string) {
+	summaryPath := os.Getenv("GITHUB_STEP_SUMMARY")
+	if summaryPath == "" {
+		return
+	}
+
+	f, err := os.OpenFile(summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, markdown)
+}
+
 func Break() {
 	fmt.Println()
 }
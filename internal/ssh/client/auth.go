@@ -0,0 +1,123 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func buildAuthMethod(ep endpoint, methods []string, agentClient agent.ExtendedAgent) (ssh.AuthMethod, error) {
+	var signers []ssh.Signer
+
+	for _, method := range methods {
+		switch method {
+		case "key":
+			if ep.key == "" {
+				continue
+			}
+			keySigners, err := loadKeySigners(ep.key, ep.passphrase, ep.certificate)
+			if err != nil {
+				return nil, err
+			}
+			signers = append(signers, keySigners...)
+
+		case "agent":
+			if agentClient == nil {
+				continue
+			}
+			agentSigners, err := agentClient.Signers()
+			if err != nil {
+				return nil, fmt.Errorf("failed to list keys from SSH agent: %w", err)
+			}
+			logs.Verbosef("SSH agent offered %d key%s for %s", len(agentSigners), utils.Plural(len(agentSigners)), ep.addr)
+			signers = append(signers, agentSigners...)
+		}
+	}
+
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("no SSH keys available for authentication (auth methods: %s)", strings.Join(methods, ", "))
+	}
+
+	return ssh.PublicKeys(signers...), nil
+}
+
+func loadKeySigners(key, passphrase, certificate string) ([]ssh.Signer, error) {
+	signer, err := loadSigner(key, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate == "" {
+		return []ssh.Signer{signer}, nil
+	}
+
+	cert, err := parseCertificate(certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("SSH certificate does not match the private key: %w", err)
+	}
+
+	return []ssh.Signer{certSigner, signer}, nil
+}
+
+func loadSigner(key, passphrase string) (ssh.Signer, error) {
+	keyBytes := []byte(key)
+
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		if _, ok := err.(*ssh.PassphraseMissingError); ok && passphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(keyBytes, []byte(passphrase))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt SSH key using passphrase: %w", err)
+			}
+		} else {
+			return nil, fmt.Errorf("failed to parse SSH private key: %w", err)
+		}
+	}
+
+	return signer, nil
+}
+
+func parseCertificate(certificate string) (*ssh.Certificate, error) {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(certificate)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH certificate: %w", err)
+	}
+
+	cert, ok := pub.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("ssh_certificate contains a public key, not a certificate")
+	}
+	if cert.CertType != ssh.UserCert {
+		return nil, fmt.Errorf("ssh_certificate is not a user certificate")
+	}
+
+	now := uint64(time.Now().Unix())
+	if cert.ValidBefore != ssh.CertTimeInfinity && now >= cert.ValidBefore {
+		return nil, fmt.Errorf("SSH certificate expired at %s", time.Unix(int64(cert.ValidBefore), 0).UTC().Format(time.RFC3339))
+	}
+	if now < cert.ValidAfter {
+		return nil, fmt.Errorf("SSH certificate is not valid until %s", time.Unix(int64(cert.ValidAfter), 0).UTC().Format(time.RFC3339))
+	}
+
+	logs.Verbosef("Using SSH certificate %q (principals: %s)", cert.KeyId, strings.Join(cert.ValidPrincipals, ", "))
+	return cert, nil
+}
+
+func connectAgent(socket string) (agent.ExtendedAgent, net.Conn, error) {
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to SSH agent at %s: %w", socket, err)
+	}
+	return agent.NewClient(conn), conn, nil
+}
@@ -1,111 +1,220 @@
 package client
 
 import (
-	"crypto/subtle"
+	"context"
 	"fmt"
 	"net"
-	"os"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/alcharra/docker-deploy-action-go/config"
 	"github.com/alcharra/docker-deploy-action-go/internal/logs"
 	"golang.org/x/crypto/ssh"
-	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/crypto/ssh/agent"
 )
 
-func NewClient(cfg config.DeployConfig) (*Client, error) {
-	keyBytes := []byte(cfg.SSHKey)
-
-	var signer ssh.Signer
-	var err error
-
-	signer, err = ssh.ParsePrivateKey(keyBytes)
+func NewClient(ctx context.Context, cfg config.DeployConfig) (*Client, error) {
+	timeout, err := parseDuration("ssh_timeout", cfg.SSHTimeout, 10*time.Second)
 	if err != nil {
-		if _, ok := err.(*ssh.PassphraseMissingError); ok && cfg.SSHKeyPassphrase != "" {
-			signer, err = ssh.ParsePrivateKeyWithPassphrase(keyBytes, []byte(cfg.SSHKeyPassphrase))
-			if err != nil {
-				return nil, fmt.Errorf("failed to decrypt SSH key using passphrase: %w", err)
-			}
-		} else {
-			return nil, fmt.Errorf("failed to parse SSH private key: %w", err)
-		}
+		return nil, err
+	}
+	retryDelay, err := parseDuration("ssh_connect_retry_delay", cfg.SSHConnectRetryDelay, 2*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	keepaliveInterval, err := parseDuration("ssh_keepalive_interval", cfg.SSHKeepaliveInterval, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	var hostKeyCallback ssh.HostKeyCallback
+	methods := cfg.SSHAuthMethods
+	if len(methods) == 0 {
+		methods = []string{"key", "agent"}
+	}
 
-	switch {
-	case cfg.SSHKnownHosts != "":
-		tmpFile, err := os.CreateTemp("", "known_hosts")
+	var agentClient agent.ExtendedAgent
+	var agentConn net.Conn
+	if cfg.SSHAgentSocket != "" && slices.Contains(methods, "agent") {
+		agentClient, agentConn, err = connectAgent(cfg.SSHAgentSocket)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create temporary known_hosts file: %w", err)
+			if cfg.SSHKey == "" {
+				return nil, err
+			}
+			logs.Warnf("%v – falling back to ssh_key", err)
 		}
-		defer tmpFile.Close()
+	}
 
-		if _, err := tmpFile.WriteString(cfg.SSHKnownHosts); err != nil {
-			return nil, fmt.Errorf("failed to write contents to known_hosts file: %w", err)
-		}
+	d := &dialer{
+		retries:    max(cfg.SSHConnectRetries, 0),
+		retryDelay: retryDelay,
+	}
 
-		hostKeyCallback, err = knownhosts.New(tmpFile.Name())
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse known_hosts data: %w", err)
-		}
+	for i, jump := range cfg.SSHJumpHosts {
+		ep := jumpEndpoint(jump, cfg)
 
-	case cfg.SSHFingerprint != "":
-		expected := strings.TrimSpace(cfg.SSHFingerprint)
-		hostKeyCallback = func(hostname string, remote net.Addr, key ssh.PublicKey) error {
-			actual := ssh.FingerprintSHA256(key)
-			if subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) != 1 {
-				return fmt.Errorf("SSH host key mismatch – got %s, expected %s", actual, expected)
+		clientConfig, err := buildClientConfig(ep, methods, agentClient, timeout)
+		if err != nil {
+			if agentConn != nil {
+				agentConn.Close()
 			}
-			return nil
+			return nil, fmt.Errorf("jump host %d (%s): %w", i+1, ep.addr, err)
 		}
-
-	default:
-		logs.Warn("Host key verification is disabled (not recommended for production)")
-		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+		d.hops = append(d.hops, hop{label: fmt.Sprintf("jump host %d (%s)", i+1, ep.addr), addr: ep.addr, config: clientConfig})
 	}
 
-	timeout := 10 * time.Second
-	if cfg.SSHTimeout != "" {
-		if parsed, err := time.ParseDuration(cfg.SSHTimeout); err == nil {
-			timeout = parsed
-		} else {
-			return nil, fmt.Errorf("invalid SSH timeout duration: %w", err)
+	ep := targetEndpoint(cfg)
+
+	clientConfig, err := buildClientConfig(ep, methods, agentClient, timeout)
+	if err != nil {
+		if agentConn != nil {
+			agentConn.Close()
 		}
+		return nil, err
 	}
+	d.hops = append(d.hops, hop{label: "SSH host", addr: ep.addr, config: clientConfig})
 
-	clientConfig := &ssh.ClientConfig{
-		User:            cfg.SSHUser,
-		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
-		HostKeyCallback: hostKeyCallback,
-		Timeout:         timeout,
+	conn, jumpClients, err := d.connect(ctx)
+	if err != nil {
+		if agentConn != nil {
+			agentConn.Close()
+		}
+		return nil, err
 	}
 
-	conn, err := ssh.Dial("tcp", fmt.Sprintf("%s:%s", cfg.SSHHost, cfg.SSHPort), clientConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to SSH host: %w", err)
+	c := &connection{
+		client:    conn,
+		jumps:     jumpClients,
+		agentConn: agentConn,
+		dialer:    d,
+		keepalive: keepaliveSettings{interval: keepaliveInterval, maxMissed: max(cfg.SSHKeepaliveMaxMissed, 1)},
+		state:     newConnState(),
 	}
+	c.startKeepalive(c.client, c.state)
 
 	return &Client{
 		Host:       cfg.SSHHost,
 		Port:       cfg.SSHPort,
 		User:       cfg.SSHUser,
 		PrivateKey: cfg.SSHKey,
-		sshClient:  conn,
+		ctx:        ctx,
+		conn:       c,
+	}, nil
+}
+
+func buildClientConfig(ep endpoint, methods []string, agentClient agent.ExtendedAgent, timeout time.Duration) (*ssh.ClientConfig, error) {
+	auth, err := buildAuthMethod(ep, methods, agentClient)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := buildHostKeyCallback(ep)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User: ep.user,
+		Auth: []ssh.AuthMethod{auth},
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if err := hostKeyCallback(hostname, remote, key); err != nil {
+				return &permanentError{err: err}
+			}
+			return nil
+		},
+		Timeout: timeout,
 	}, nil
 }
 
+func parseDuration(name, val string, fallback time.Duration) (time.Duration, error) {
+	if val == "" {
+		return fallback, nil
+	}
+	parsed, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s duration: %w", name, err)
+	}
+	return parsed, nil
+}
+
+func targetEndpoint(cfg config.DeployConfig) endpoint {
+	return endpoint{
+		addr:        net.JoinHostPort(cfg.SSHHost, cfg.SSHPort),
+		user:        cfg.SSHUser,
+		key:         cfg.SSHKey,
+		passphrase:  cfg.SSHKeyPassphrase,
+		certificate: cfg.SSHCertificate,
+		knownHosts:  cfg.SSHKnownHosts,
+		fingerprint: cfg.SSHFingerprint,
+		hostCA:      cfg.SSHHostCA,
+		revokedKeys: cfg.SSHRevokedKeys,
+	}
+}
+
+func jumpEndpoint(jump config.JumpHost, cfg config.DeployConfig) endpoint {
+	port := jump.Port
+	if port == "" {
+		port = "22"
+	}
+
+	ep := endpoint{
+		addr:        net.JoinHostPort(jump.Host, port),
+		user:        jump.User,
+		key:         jump.Key,
+		passphrase:  jump.KeyPassphrase,
+		certificate: jump.Certificate,
+		knownHosts:  jump.KnownHosts,
+		fingerprint: jump.Fingerprint,
+		hostCA:      cfg.SSHHostCA,
+		revokedKeys: cfg.SSHRevokedKeys,
+	}
+
+	if ep.user == "" {
+		ep.user = cfg.SSHUser
+	}
+	if ep.key == "" && cfg.SSHJumpKey != "" {
+		ep.key = cfg.SSHJumpKey
+		ep.passphrase = cfg.SSHJumpKeyPassphrase
+		ep.certificate = cfg.SSHJumpCertificate
+	}
+	if ep.key == "" {
+		ep.key = cfg.SSHKey
+		ep.passphrase = cfg.SSHKeyPassphrase
+		ep.certificate = cfg.SSHCertificate
+	}
+	if ep.knownHosts == "" && ep.fingerprint == "" {
+		ep.knownHosts = cfg.SSHJumpKnownHosts
+		ep.fingerprint = cfg.SSHJumpFingerprint
+	}
+	if ep.knownHosts == "" && ep.fingerprint == "" {
+		ep.knownHosts = cfg.SSHKnownHosts
+	}
+	return ep
+}
+
+func (cli *Client) Context() context.Context {
+	if cli.ctx == nil {
+		return context.Background()
+	}
+	return cli.ctx
+}
+
+func (cli *Client) WithContext(ctx context.Context) *Client {
+	clone := *cli
+	clone.ctx = ctx
+	return &clone
+}
+
 func (cli *Client) NewSession() (*ssh.Session, error) {
-	if cli.sshClient == nil {
+	if cli.conn == nil {
 		return nil, fmt.Errorf("SSH client is not initialised")
 	}
-	return cli.sshClient.NewSession()
+	client, state := cli.conn.current()
+	return newSession(client, state)
 }
 
 func (cli *Client) Close() error {
-	if cli.sshClient == nil {
+	if cli.conn == nil {
 		return nil
 	}
-	return cli.sshClient.Close()
+	return cli.conn.close()
 }
@@ -0,0 +1,36 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"testing"
+
+	"github.com/alcharra/docker-deploy-action-go/config"
+)
+
+func TestJumpEndpoint_Credentials(t *testing.T) {
+	cfg := config.DeployConfig{
+		SSHUser:       "deployer",
+		SSHKey:        "target-key",
+		SSHKnownHosts: "target-known-hosts",
+	}
+
+	ep := jumpEndpoint(config.JumpHost{Host: "bastion"}, cfg)
+	if ep.addr != "bastion:22" || ep.user != "deployer" || ep.key != "target-key" || ep.knownHosts != "target-known-hosts" {
+		t.Errorf("expected hop to reuse target settings, got %+v", ep)
+	}
+
+	cfg.SSHJumpKey = "jump-key"
+	cfg.SSHJumpKeyPassphrase = "secret"
+	cfg.SSHJumpFingerprint = "SHA256:abc"
+	ep = jumpEndpoint(config.JumpHost{Host: "bastion"}, cfg)
+	if ep.key != "jump-key" || ep.passphrase != "secret" || ep.fingerprint != "SHA256:abc" || ep.knownHosts != "" {
+		t.Errorf("expected hop to use ssh_jump_* settings, got %+v", ep)
+	}
+
+	ep = jumpEndpoint(config.JumpHost{Host: "bastion", Key: "hop-key", KnownHosts: "hop-known-hosts"}, cfg)
+	if ep.key != "hop-key" || ep.passphrase != "" || ep.knownHosts != "hop-known-hosts" || ep.fingerprint != "" {
+		t.Errorf("expected hop's own settings to win, got %+v", ep)
+	}
+}
@@ -2,14 +2,84 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	maxReconnects     = 2
+	signalGracePeriod = 5 * time.Second
 )
 
 func (cli *Client) RunCommandBuffered(cmd string) (string, string, error) {
-	session, err := cli.sshClient.NewSession()
+	return cli.RunCommandBufferedContext(cli.Context(), cmd)
+}
+
+func (cli *Client) RunCommandStreamed(cmd string) error {
+	return cli.RunCommandStreamedContext(cli.Context(), cmd)
+}
+
+func (cli *Client) RunIdempotentBuffered(cmd string) (string, string, error) {
+	return cli.RunIdempotentBufferedContext(cli.Context(), cmd)
+}
+
+func (cli *Client) RunIdempotentStreamed(cmd string) error {
+	return cli.RunIdempotentStreamedContext(cli.Context(), cmd)
+}
+
+func (cli *Client) RunCommandBufferedContext(ctx context.Context, cmd string) (string, string, error) {
+	stdout, stderr, _, err := cli.runBuffered(ctx, cmd)
+	return stdout, stderr, err
+}
+
+func (cli *Client) RunCommandStreamedContext(ctx context.Context, cmd string) error {
+	_, err := cli.runStreamed(ctx, cmd)
+	return err
+}
+
+func (cli *Client) RunIdempotentBufferedContext(ctx context.Context, cmd string) (string, string, error) {
+	for attempt := 1; ; attempt++ {
+		stdout, stderr, used, err := cli.runBuffered(ctx, cmd)
+		if !shouldReconnect(ctx, err, attempt) {
+			return stdout, stderr, err
+		}
+		if err := cli.reconnect(ctx, used, err); err != nil {
+			return stdout, stderr, err
+		}
+	}
+}
+
+func (cli *Client) RunIdempotentStreamedContext(ctx context.Context, cmd string) error {
+	for attempt := 1; ; attempt++ {
+		used, err := cli.runStreamed(ctx, cmd)
+		if !shouldReconnect(ctx, err, attempt) {
+			return err
+		}
+		if err := cli.reconnect(ctx, used, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (cli *Client) runBuffered(ctx context.Context, cmd string) (string, string, *ssh.Client, error) {
+	if cli.conn == nil {
+		return "", "", nil, fmt.Errorf("SSH client is not initialised")
+	}
+	if ctx.Err() != nil {
+		return "", "", nil, context.Cause(ctx)
+	}
+	client, state := cli.conn.current()
+
+	session, err := newSession(client, state)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to create SSH session: %w", err)
+		return "", "", client, fmt.Errorf("failed to create SSH session: %w", err)
 	}
 	defer session.Close()
 
@@ -17,29 +87,45 @@ func (cli *Client) RunCommandBuffered(cmd string) (string, string, error) {
 	session.Stdout = &stdout
 	session.Stderr = &stderr
 
+	stop := cancelOnDone(ctx, session)
 	err = session.Run(cmd)
-	return stdout.String(), stderr.String(), err
+	stop()
+
+	if cause := interruption(ctx, state); err != nil && cause != nil {
+		err = cause
+	}
+	return stdout.String(), stderr.String(), client, err
 }
 
-func (cli *Client) RunCommandStreamed(cmd string) error {
-	session, err := cli.sshClient.NewSession()
+func (cli *Client) runStreamed(ctx context.Context, cmd string) (*ssh.Client, error) {
+	if cli.conn == nil {
+		return nil, fmt.Errorf("SSH client is not initialised")
+	}
+	if ctx.Err() != nil {
+		return nil, context.Cause(ctx)
+	}
+	client, state := cli.conn.current()
+
+	session, err := newSession(client, state)
 	if err != nil {
-		return fmt.Errorf("failed to create SSH session: %w", err)
+		return client, fmt.Errorf("failed to create SSH session: %w", err)
 	}
 	defer session.Close()
 
 	stdout, err := session.StdoutPipe()
 	if err != nil {
-		return fmt.Errorf("failed to get stdout pipe: %w", err)
+		return client, fmt.Errorf("failed to get stdout pipe: %w", err)
 	}
 	stderr, err := session.StderrPipe()
 	if err != nil {
-		return fmt.Errorf("failed to get stderr pipe: %w", err)
+		return client, fmt.Errorf("failed to get stderr pipe: %w", err)
 	}
 
 	if err := session.Start(cmd); err != nil {
-		return fmt.Errorf("failed to start remote command: %w", err)
+		return client, fmt.Errorf("failed to start remote command: %w", err)
 	}
+	stop := cancelOnDone(ctx, session)
+	defer stop()
 
 	if strings.Contains(cmd, "docker compose") {
 		go streamComposeOutput(stdout)
@@ -50,8 +136,63 @@ func (cli *Client) RunCommandStreamed(cmd string) error {
 	}
 
 	if err := session.Wait(); err != nil {
-		return fmt.Errorf("remote command failed: %w", err)
+		if cause := interruption(ctx, state); cause != nil {
+			return client, cause
+		}
+		return client, fmt.Errorf("remote command failed: %w", err)
+	}
+
+	return client, nil
+}
+
+func cancelOnDone(ctx context.Context, session *ssh.Session) func() {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			logs.Verbosef("Cancelling remote command: %v", context.Cause(ctx))
+			session.Signal(ssh.SIGTERM)
+			select {
+			case <-finished:
+			case <-time.After(signalGracePeriod):
+			}
+			session.Close()
+		case <-finished:
+		}
+	}()
+
+	return sync.OnceFunc(func() { close(finished) })
+}
+
+func interruption(ctx context.Context, state *connState) error {
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
 	}
+	return state.lost()
+}
+
+func (cli *Client) reconnect(ctx context.Context, stale *ssh.Client, cause error) error {
+	logs.Warnf("Lost connection to %s while running an idempotent command: %v", cli.Host, cause)
+	logs.Info("Reconnecting and retrying the command...")
 
+	if err := cli.conn.reconnect(ctx, stale); err != nil {
+		return fmt.Errorf("%w (reconnect failed: %v)", cause, err)
+	}
 	return nil
 }
+
+func shouldReconnect(ctx context.Context, err error, attempt int) bool {
+	if err == nil || attempt > maxReconnects || ctx.Err() != nil {
+		return false
+	}
+	return isConnectionError(err)
+}
+
+func isConnectionError(err error) bool {
+	var exitErr *ssh.ExitError
+	return !errors.As(err, &exitErr)
+}
@@ -0,0 +1,39 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestShouldReconnect(t *testing.T) {
+	exitErr := fmt.Errorf("remote command failed: %w", &ssh.ExitError{})
+
+	tests := []struct {
+		name     string
+		err      error
+		attempt  int
+		expected bool
+	}{
+		{"success", nil, 1, false},
+		{"connection dropped", fmt.Errorf("failed to create SSH session: %w", io.EOF), 1, true},
+		{"missing exit status", &ssh.ExitMissingError{}, 2, true},
+		{"command failed", exitErr, 1, false},
+		{"reconnects exhausted", errors.New("connection reset by peer"), maxReconnects + 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldReconnect(context.Background(), tt.err, tt.attempt); got != tt.expected {
+				t.Errorf("shouldReconnect() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"golang.org/x/crypto/ssh"
+)
+
+func (c *connection) current() (*ssh.Client, *connState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client, c.state
+}
+
+func (c *connection) reconnect(ctx context.Context, stale *ssh.Client) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return fmt.Errorf("SSH client is closed")
+	}
+	if c.client != stale {
+		// Another command already re-established the connection.
+		return nil
+	}
+
+	c.closeCurrent()
+
+	client, jumps, err := c.dialer.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.client = client
+	c.jumps = jumps
+	c.state = newConnState()
+	c.startKeepalive(c.client, c.state)
+
+	logs.Success("SSH connection re-established")
+	return nil
+}
+
+func (c *connection) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	err := c.closeCurrent()
+	if c.agentConn != nil {
+		c.agentConn.Close()
+	}
+	return err
+}
+
+func (c *connection) closeCurrent() error {
+	c.state.stop()
+	err := c.client.Close()
+	for i := len(c.jumps) - 1; i >= 0; i-- {
+		c.jumps[i].Close()
+	}
+	return err
+}
+
+func newSession(client *ssh.Client, state *connState) (*ssh.Session, error) {
+	if lostErr := state.lost(); lostErr != nil {
+		return nil, lostErr
+	}
+	return client.NewSession()
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"golang.org/x/crypto/ssh"
+)
+
+const maxRetryDelay = 30 * time.Second
+
+func (d *dialer) connect(ctx context.Context) (*ssh.Client, []*ssh.Client, error) {
+	attempts := d.retries + 1
+
+	for attempt := 1; ; attempt++ {
+		conn, jumpClients, err := d.connectOnce(ctx)
+		if err == nil {
+			if attempt > 1 {
+				logs.Verbosef("Connected on attempt %d/%d", attempt, attempts)
+			}
+			return conn, jumpClients, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, nil, context.Cause(ctx)
+		}
+		if attempt >= attempts || !isRetryable(err) {
+			return nil, nil, err
+		}
+
+		delay := backoff(d.retryDelay, attempt)
+		logs.Warnf("Connection attempt %d/%d failed: %v – retrying in %s", attempt, attempts, err, delay.Round(time.Millisecond))
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, context.Cause(ctx)
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (d *dialer) connectOnce(ctx context.Context) (*ssh.Client, []*ssh.Client, error) {
+	var jumpClients []*ssh.Client
+	var via *ssh.Client
+
+	for i, h := range d.hops {
+		conn, err := dial(ctx, via, h.addr, h.config)
+		if err != nil {
+			for j := len(jumpClients) - 1; j >= 0; j-- {
+				jumpClients[j].Close()
+			}
+			return nil, nil, fmt.Errorf("failed to connect to %s: %w", h.label, err)
+		}
+
+		if i == len(d.hops)-1 {
+			return conn, jumpClients, nil
+		}
+		jumpClients = append(jumpClients, conn)
+		via = conn
+	}
+
+	return nil, nil, fmt.Errorf("no SSH host to connect to")
+}
+
+func dial(ctx context.Context, via *ssh.Client, addr string, clientConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	var netConn net.Conn
+	var err error
+	if via == nil {
+		dialer := net.Dialer{Timeout: clientConfig.Timeout}
+		netConn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		dialCtx := ctx
+		if clientConfig.Timeout > 0 {
+			var cancel context.CancelFunc
+			dialCtx, cancel = context.WithTimeout(ctx, clientConfig.Timeout)
+			defer cancel()
+		}
+		netConn, err = via.DialContext(dialCtx, "tcp", addr)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			err = fmt.Errorf("timed out after %s connecting to %s through the jump host", clientConfig.Timeout, addr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn, chans, reqs, err := ssh.NewClientConn(netConn, addr, clientConfig)
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return ssh.NewClient(conn, chans, reqs), nil
+}
+
+func isRetryable(err error) bool {
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+	return !strings.Contains(err.Error(), "unable to authenticate")
+}
+
+func backoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+
+	delay := base << (attempt - 1)
+	if delay > maxRetryDelay || delay <= 0 {
+		delay = maxRetryDelay
+	}
+
+	// Jitter within the upper half of the window spreads retries out
+	// without ever retrying immediately.
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+func (d *dialer) target() string {
+	return d.hops[len(d.hops)-1].addr
+}
@@ -0,0 +1,49 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	base := time.Second
+
+	for attempt, window := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: maxRetryDelay} {
+		for range 20 {
+			delay := backoff(base, attempt)
+			if delay < window/2 || delay > window {
+				t.Fatalf("attempt %d: delay %s outside [%s, %s]", attempt, delay, window/2, window)
+			}
+		}
+	}
+
+	if delay := backoff(0, 3); delay != 0 {
+		t.Errorf("expected no delay for a zero base, got %s", delay)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"connection refused", errors.New("dial tcp 10.0.0.1:22: connect: connection refused"), true},
+		{"timeout", errors.New("dial tcp 10.0.0.1:22: i/o timeout"), true},
+		{"auth failure", errors.New("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey]"), false},
+		{"host key mismatch", fmt.Errorf("ssh: handshake failed: %w", &permanentError{err: errors.New("SSH host key mismatch")}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.expected {
+				t.Errorf("isRetryable() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"golang.org/x/crypto/ssh"
+)
+
+var md5FingerprintPattern = regexp.MustCompile(`^[0-9a-f]{2}(:[0-9a-f]{2}){15}$`)
+
+func parseFingerprints(raw string) ([]hostFingerprint, error) {
+	var fingerprints []hostFingerprint
+
+	for _, entry := range strings.FieldsFunc(raw, func(r rune) bool { return r == '\n' || r == ',' || r == ' ' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fp, err := parseFingerprint(entry)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints = append(fingerprints, fp)
+	}
+
+	return fingerprints, nil
+}
+
+func parseFingerprint(entry string) (hostFingerprint, error) {
+	prefix, value, found := strings.Cut(entry, ":")
+	if found {
+		switch strings.ToUpper(prefix) {
+		case "SHA256":
+			return hostFingerprint{hash: "SHA256", value: "SHA256:" + strings.TrimRight(value, "="), raw: entry}, nil
+		case "MD5":
+			value = strings.ToLower(value)
+			if md5FingerprintPattern.MatchString(value) {
+				return hostFingerprint{hash: "MD5", value: value, raw: entry}, nil
+			}
+		}
+	}
+
+	if lower := strings.ToLower(entry); md5FingerprintPattern.MatchString(lower) {
+		return hostFingerprint{hash: "MD5", value: lower, raw: entry}, nil
+	}
+
+	return hostFingerprint{}, fmt.Errorf("unrecognised SSH fingerprint '%s' (expected SHA256:<base64> or MD5:<hex pairs>)", entry)
+}
+
+func fingerprintCallback(fingerprints []hostFingerprint) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		actual := map[string]string{
+			"SHA256": ssh.FingerprintSHA256(key),
+			"MD5":    ssh.FingerprintLegacyMD5(key),
+		}
+
+		for _, fp := range fingerprints {
+			if subtle.ConstantTimeCompare([]byte(actual[fp.hash]), []byte(fp.value)) == 1 {
+				logs.Infof("Host key for %s matched fingerprint %s", hostname, fp.raw)
+				return nil
+			}
+		}
+
+		expected := make([]string, 0, len(fingerprints))
+		for _, fp := range fingerprints {
+			expected = append(expected, fp.raw)
+		}
+		return fmt.Errorf("SSH host key mismatch – got %s (MD5:%s), expected one of: %s", actual["SHA256"], actual["MD5"], strings.Join(expected, ", "))
+	}
+}
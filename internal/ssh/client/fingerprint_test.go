@@ -0,0 +1,66 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseFingerprints(t *testing.T) {
+	fingerprints, err := parseFingerprints(`
+		SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8=
+		md5:16:27:AC:A5:76:28:2D:36:63:1B:56:4D:EB:DF:A6:48, 16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48
+	`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []hostFingerprint{
+		{hash: "SHA256", value: "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", raw: "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8="},
+		{hash: "MD5", value: "16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48", raw: "md5:16:27:AC:A5:76:28:2D:36:63:1B:56:4D:EB:DF:A6:48"},
+		{hash: "MD5", value: "16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48", raw: "16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48"},
+	}
+	if len(fingerprints) != len(expected) {
+		t.Fatalf("expected %d fingerprints, got %+v", len(expected), fingerprints)
+	}
+	for i := range expected {
+		if fingerprints[i] != expected[i] {
+			t.Errorf("fingerprint %d: expected %+v, got %+v", i, expected[i], fingerprints[i])
+		}
+	}
+}
+
+func TestParseFingerprints_Invalid(t *testing.T) {
+	for _, raw := range []string{"nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8", "MD5:16:27", "SHA1:abc"} {
+		if _, err := parseFingerprints(raw); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestFingerprintCallback(t *testing.T) {
+	key := newTestSigner(t).PublicKey()
+	other := newTestSigner(t).PublicKey()
+
+	fingerprints, err := parseFingerprints(ssh.FingerprintSHA256(other) + "\nMD5:" + ssh.FingerprintLegacyMD5(key))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	callback := fingerprintCallback(fingerprints)
+
+	if err := callback("example.com:22", nil, key); err != nil {
+		t.Errorf("expected MD5 fingerprint to match, got: %v", err)
+	}
+	if err := callback("example.com:22", nil, other); err != nil {
+		t.Errorf("expected SHA256 fingerprint to match, got: %v", err)
+	}
+
+	err = callback("example.com:22", nil, newTestSigner(t).PublicKey())
+	if err == nil || !strings.Contains(err.Error(), "expected one of") {
+		t.Errorf("expected mismatch error, got: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func buildHostKeyCallback(ep endpoint) (ssh.HostKeyCallback, error) {
+	if ep.hostCA == "" {
+		return plainHostKeyCallback(ep.addr, ep.knownHosts, ep.fingerprint, true)
+	}
+
+	authorities, err := parseHostCAs(ep.hostCA)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := parseRevokedKeys(ep.revokedKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback, err := plainHostKeyCallback(ep.addr, ep.knownHosts, ep.fingerprint, false)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := &ssh.CertChecker{
+		IsHostAuthority: func(auth ssh.PublicKey, address string) bool {
+			for _, ca := range authorities {
+				if bytes.Equal(auth.Marshal(), ca.Marshal()) {
+					return true
+				}
+			}
+			return false
+		},
+		IsRevoked:       revoked.contains,
+		HostKeyFallback: fallback,
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if err := checker.CheckHostKey(hostname, remote, key); err != nil {
+			return err
+		}
+		if cert, ok := key.(*ssh.Certificate); ok {
+			logs.Verbosef("Host certificate for %s verified (key ID: %q, valid until %s)", hostname, cert.KeyId, certExpiry(cert))
+		}
+		return nil
+	}, nil
+}
+
+func plainHostKeyCallback(target, knownHosts, fingerprint string, allowInsecure bool) (ssh.HostKeyCallback, error) {
+	switch {
+	case knownHosts != "":
+		tmpFile, err := os.CreateTemp("", "known_hosts")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary known_hosts file: %w", err)
+		}
+		defer os.Remove(tmpFile.Name())
+		defer tmpFile.Close()
+
+		if _, err := tmpFile.WriteString(knownHosts); err != nil {
+			return nil, fmt.Errorf("failed to write contents to known_hosts file: %w", err)
+		}
+
+		callback, err := knownhosts.New(tmpFile.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse known_hosts data: %w", err)
+		}
+		return callback, nil
+
+	case strings.TrimSpace(fingerprint) != "":
+		fingerprints, err := parseFingerprints(fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		return fingerprintCallback(fingerprints), nil
+
+	case !allowInsecure:
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			return fmt.Errorf("host %s presented a plain host key (%s) instead of a certificate signed by ssh_host_ca", hostname, ssh.FingerprintSHA256(key))
+		}, nil
+
+	default:
+		logs.Warnf("Host key verification is disabled for %s (not recommended for production)", target)
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+}
+
+func parseHostCAs(raw string) ([]ssh.PublicKey, error) {
+	var authorities []ssh.PublicKey
+
+	for line := range strings.SplitSeq(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Accept known_hosts style "@cert-authority <hosts> <key>" entries as well as bare keys.
+		if strings.HasPrefix(line, "@cert-authority") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid ssh_host_ca entry: %s", line)
+			}
+			line = strings.Join(fields[2:], " ")
+		}
+
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ssh_host_ca entry: %w", err)
+		}
+		if _, ok := key.(*ssh.Certificate); ok {
+			return nil, fmt.Errorf("ssh_host_ca must contain CA public keys, not certificates")
+		}
+		authorities = append(authorities, key)
+	}
+
+	if len(authorities) == 0 {
+		return nil, fmt.Errorf("ssh_host_ca does not contain any public keys")
+	}
+
+	return authorities, nil
+}
+
+func parseRevokedKeys(raw string) (revokedKeys, error) {
+	revoked := revokedKeys{keys: map[string]bool{}, serials: map[uint64]bool{}}
+
+	for line := range strings.SplitSeq(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if serial, ok := strings.CutPrefix(line, "serial:"); ok {
+			n, err := strconv.ParseUint(strings.TrimSpace(serial), 10, 64)
+			if err != nil {
+				return revoked, fmt.Errorf("invalid certificate serial in ssh_revoked_keys: %s", line)
+			}
+			revoked.serials[n] = true
+			continue
+		}
+
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return revoked, fmt.Errorf("failed to parse ssh_revoked_keys entry: %w", err)
+		}
+		if cert, ok := key.(*ssh.Certificate); ok {
+			key = cert.Key
+		}
+		revoked.keys[string(key.Marshal())] = true
+	}
+
+	return revoked, nil
+}
+
+func (r revokedKeys) contains(cert *ssh.Certificate) bool {
+	switch {
+	case r.serials[cert.Serial]:
+		logs.Warnf("Host certificate serial %d has been revoked", cert.Serial)
+		return true
+	case r.keys[string(cert.Key.Marshal())]:
+		logs.Warnf("Host key %s has been revoked", ssh.FingerprintSHA256(cert.Key))
+		return true
+	case r.keys[string(cert.SignatureKey.Marshal())]:
+		logs.Warnf("Host CA %s has been revoked", ssh.FingerprintSHA256(cert.SignatureKey))
+		return true
+	}
+	return false
+}
+
+func certExpiry(cert *ssh.Certificate) string {
+	if cert.ValidBefore == ssh.CertTimeInfinity {
+		return "forever"
+	}
+	return time.Unix(int64(cert.ValidBefore), 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,95 @@
+//go:build unit
+// +build unit
+
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	return signer
+}
+
+func newHostCert(t *testing.T, ca, host ssh.Signer, serial uint64) *ssh.Certificate {
+	t.Helper()
+	cert := &ssh.Certificate{
+		Key:             host.PublicKey(),
+		Serial:          serial,
+		CertType:        ssh.HostCert,
+		ValidPrincipals: []string{"example.com"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	return cert
+}
+
+func TestParseHostCAs(t *testing.T) {
+	ca := newTestSigner(t)
+	line := string(ssh.MarshalAuthorizedKey(ca.PublicKey()))
+
+	authorities, err := parseHostCAs("# internal CA\n" + line + "@cert-authority *.example.com " + line)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(authorities) != 2 {
+		t.Fatalf("expected 2 authorities, got %d", len(authorities))
+	}
+
+	for _, raw := range []string{"", "not a key", "@cert-authority *"} {
+		if _, err := parseHostCAs(raw); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestRevokedKeys(t *testing.T) {
+	ca := newTestSigner(t)
+	host := newTestSigner(t)
+	cert := newHostCert(t, ca, host, 42)
+
+	tests := []struct {
+		name     string
+		raw      string
+		expected bool
+	}{
+		{"empty", "", false},
+		{"serial", "serial:42", true},
+		{"other serial", "serial:7", false},
+		{"host key", string(ssh.MarshalAuthorizedKey(host.PublicKey())), true},
+		{"host certificate", string(ssh.MarshalAuthorizedKey(cert)), true},
+		{"ca key", string(ssh.MarshalAuthorizedKey(ca.PublicKey())), true},
+		{"unrelated key", string(ssh.MarshalAuthorizedKey(newTestSigner(t).PublicKey())), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoked, err := parseRevokedKeys(tt.raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := revoked.contains(cert); got != tt.expected {
+				t.Errorf("contains() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := parseRevokedKeys("serial:abc"); err == nil {
+		t.Error("expected error for invalid serial")
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/logs"
+	"golang.org/x/crypto/ssh"
+)
+
+func (c *connection) startKeepalive(client *ssh.Client, state *connState) {
+	interval, maxMissed := c.keepalive.interval, c.keepalive.maxMissed
+	if interval <= 0 {
+		return
+	}
+
+	host := c.dialer.target()
+	logs.Verbosef("Sending SSH keepalives every %s (max %d missed)", interval, maxMissed)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		missed := 0
+		for {
+			select {
+			case <-state.done:
+				return
+			case <-ticker.C:
+			}
+
+			if sendKeepalive(client, state, interval) {
+				missed = 0
+				continue
+			}
+
+			missed++
+			logs.Verbosef("No keepalive reply from %s (%d/%d)", host, missed, maxMissed)
+			if missed < maxMissed {
+				continue
+			}
+
+			state.markLost(fmt.Errorf("SSH connection to %s lost: no keepalive reply after %d attempts (%s apart)", host, missed, interval))
+			client.Close()
+			return
+		}
+	}()
+}
+
+func sendKeepalive(client *ssh.Client, state *connState, timeout time.Duration) bool {
+	reply := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		reply <- err
+	}()
+
+	select {
+	case err := <-reply:
+		return err == nil
+	case <-time.After(timeout):
+		return false
+	case <-state.done:
+		return true
+	}
+}
+
+func newConnState() *connState {
+	return &connState{done: make(chan struct{})}
+}
+
+func (s *connState) markLost(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lostErr == nil {
+		s.lostErr = err
+	}
+}
+
+func (s *connState) lost() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lostErr
+}
+
+func (s *connState) stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
@@ -1,11 +1,81 @@
 package client
 
-import "golang.org/x/crypto/ssh"
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
 
 type Client struct {
 	Host       string
 	Port       string
 	User       string
 	PrivateKey string
-	sshClient  *ssh.Client
+	ctx        context.Context
+	conn       *connection
+}
+
+type connection struct {
+	mu        sync.Mutex
+	client    *ssh.Client
+	jumps     []*ssh.Client
+	agentConn net.Conn
+	dialer    *dialer
+	keepalive keepaliveSettings
+	state     *connState
+	closed    bool
+}
+
+type keepaliveSettings struct {
+	interval  time.Duration
+	maxMissed int
+}
+
+type connState struct {
+	done     chan struct{}
+	stopOnce sync.Once
+	mu       sync.Mutex
+	lostErr  error
+}
+
+type dialer struct {
+	hops       []hop
+	retries    int
+	retryDelay time.Duration
+}
+
+type hop struct {
+	label  string
+	addr   string
+	config *ssh.ClientConfig
+}
+
+type permanentError struct {
+	err error
+}
+
+type endpoint struct {
+	addr        string
+	user        string
+	key         string
+	passphrase  string
+	certificate string
+	knownHosts  string
+	fingerprint string
+	hostCA      string
+	revokedKeys string
+}
+
+type hostFingerprint struct {
+	hash  string
+	value string
+	raw   string
+}
+
+type revokedKeys struct {
+	keys    map[string]bool
+	serials map[uint64]bool
 }
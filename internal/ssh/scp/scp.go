@@ -1,11 +1,10 @@
 package scp
 
 import (
+	"errors"
 	"fmt"
-	"io"
-	"os"
 	"path"
-	"path/filepath"
+	"strings"
 
 	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
 )
@@ -15,74 +14,94 @@ func UploadFileSCP(cli *client.Client, localPath, remotePath string) error {
 		return fmt.Errorf("SSH client is not initialised")
 	}
 
-	dir := path.Dir(remotePath)
-
-	session, err := cli.NewSession()
+	sink, err := openSink(cli, path.Dir(remotePath), false)
 	if err != nil {
-		return fmt.Errorf("unable to initialise SSH session for directory creation: %w", err)
-	}
-	if err := session.Run(fmt.Sprintf("mkdir -p %q", dir)); err != nil {
-		session.Close()
-		return fmt.Errorf("unable to create remote directory '%s': %w", dir, err)
+		return err
 	}
-	session.Close()
 
-	session, err = cli.NewSession()
-	if err != nil {
-		return fmt.Errorf("unable to initialise SSH session for file transfer: %w", err)
+	if err := sink.sendFile(localPath, path.Base(remotePath)); err != nil {
+		sink.abort()
+		return fmt.Errorf("SCP transfer failed for '%s': %w", localPath, err)
 	}
-	defer session.Close()
 
-	srcFile, err := os.Open(localPath)
-	if err != nil {
-		return fmt.Errorf("unable to open local file '%s': %w", localPath, err)
-	}
-	defer srcFile.Close()
+	return sink.close()
+}
 
-	info, err := srcFile.Stat()
-	if err != nil {
-		return fmt.Errorf("unable to retrieve file info for '%s': %w", localPath, err)
+func NewUploader(cli *client.Client, root string) (*Uploader, error) {
+	u := &Uploader{cli: cli, root: path.Clean(root)}
+	if err := u.open(); err != nil {
+		return nil, err
 	}
+	return u, nil
+}
 
-	stdin, err := session.StdinPipe()
-	if err != nil {
-		return fmt.Errorf("unable to open stdin pipe for SCP transfer: %w", err)
+func (u *Uploader) Upload(localPath, remotePath string) error {
+	rel, ok := RelativeTo(u.root, remotePath)
+	if !ok {
+		return UploadFileSCP(u.cli, localPath, remotePath)
 	}
 
-	errCh := make(chan error, 1)
-	go func() {
-		defer stdin.Close()
-
-		if _, err := fmt.Fprintf(stdin, "C%#o %d %s\n",
-			info.Mode().Perm(),
-			info.Size(),
-			filepath.Base(srcFile.Name()),
-		); err != nil {
-			errCh <- fmt.Errorf("unable to write file header for '%s': %w", srcFile.Name(), err)
-			return
+	if u.sink == nil {
+		if err := u.open(); err != nil {
+			return err
 		}
+	}
 
-		if _, err := io.Copy(stdin, srcFile); err != nil {
-			errCh <- fmt.Errorf("unable to copy file content for '%s': %w", srcFile.Name(), err)
-			return
+	dir, name := path.Split(rel)
+	err := u.sink.changeDir(splitDir(dir))
+	if err == nil {
+		err = u.sink.sendFile(localPath, name)
+	}
+	if err != nil {
+		// A warning leaves the sink waiting for the next command; anything
+		// else means the stream is out of step and the session is unusable.
+		var sinkErr *sinkError
+		if !errors.As(err, &sinkErr) || sinkErr.fatal {
+			u.sink.abort()
+			u.sink = nil
 		}
+		return err
+	}
 
-		if _, err := fmt.Fprint(stdin, "\x00"); err != nil {
-			errCh <- fmt.Errorf("unable to send transfer completion signal for '%s': %w", srcFile.Name(), err)
-			return
-		}
+	return nil
+}
 
-		errCh <- nil
-	}()
+func (u *Uploader) open() error {
+	if u.cli == nil {
+		return fmt.Errorf("SSH client is not initialised")
+	}
+	sink, err := openSink(u.cli, u.root, true)
+	if err != nil {
+		return err
+	}
+	u.sink = sink
+	return nil
+}
 
-	cmd := fmt.Sprintf("scp -t %s", dir)
-	if err := session.Run(cmd); err != nil {
-		return fmt.Errorf("SCP transfer failed for '%s': %w", localPath, err)
+func (u *Uploader) Close() error {
+	if u.sink == nil {
+		return nil
 	}
+	err := u.sink.close()
+	u.sink = nil
+	return err
+}
 
-	if err := <-errCh; err != nil {
-		return fmt.Errorf("failed to write SCP payload for '%s': %w", localPath, err)
+func RelativeTo(root, remotePath string) (string, bool) {
+	rel := strings.TrimPrefix(path.Clean(remotePath), root+"/")
+	if root == "/" {
+		rel = strings.TrimPrefix(path.Clean(remotePath), "/")
+	}
+	if rel == path.Clean(remotePath) || rel == "" {
+		return "", false
 	}
+	return rel, true
+}
 
-	return nil
+func splitDir(dir string) []string {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return nil
+	}
+	return strings.Split(dir, "/")
 }
@@ -0,0 +1,167 @@
+package scp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+	"golang.org/x/crypto/ssh"
+)
+
+const dirMode = 0755
+
+func openSink(cli *client.Client, target string, recursive bool) (*sinkSession, error) {
+	session, err := cli.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialise SSH session for file transfer: %w", err)
+	}
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to open stdin pipe for SCP transfer: %w", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to open stdout pipe for SCP transfer: %w", err)
+	}
+	stderr := &stderrBuffer{}
+	session.Stderr = stderr
+
+	flags := "-t"
+	if recursive {
+		flags = "-r -t"
+	}
+	cmd := fmt.Sprintf("mkdir -p %s && scp %s %s", utils.ShellQuote(target), flags, utils.ShellQuote(target))
+	if err := session.Start(cmd); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to start SCP sink in '%s': %w", target, err)
+	}
+
+	sink := &sinkSession{
+		session: session,
+		stdin:   stdin,
+		stdout:  bufio.NewReader(stdout),
+		stderr:  stderr,
+	}
+	if err := sink.readAck(); err != nil {
+		sink.abort()
+		return nil, fmt.Errorf("SCP sink in '%s' did not start: %w", target, err)
+	}
+
+	return sink, nil
+}
+
+func (s *sinkSession) changeDir(dirs []string) error {
+	common := 0
+	for common < len(s.dirs) && common < len(dirs) && s.dirs[common] == dirs[common] {
+		common++
+	}
+
+	for len(s.dirs) > common {
+		if err := s.command("E\n"); err != nil {
+			return err
+		}
+		s.dirs = s.dirs[:len(s.dirs)-1]
+	}
+
+	for _, dir := range dirs[common:] {
+		if err := s.command(fmt.Sprintf("D%04o 0 %s\n", dirMode, dir)); err != nil {
+			return err
+		}
+		s.dirs = append(s.dirs, dir)
+	}
+
+	return nil
+}
+
+func (s *sinkSession) sendFile(localPath, name string) error {
+	srcFile, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("unable to open local file '%s': %w", localPath, err)
+	}
+	defer srcFile.Close()
+
+	info, err := srcFile.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve file info for '%s': %w", localPath, err)
+	}
+
+	if err := s.command(fmt.Sprintf("C%04o %d %s\n", info.Mode().Perm(), info.Size(), name)); err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(s.stdin, srcFile, info.Size()); err != nil {
+		return fmt.Errorf("unable to copy file content for '%s': %w", localPath, err)
+	}
+
+	return s.command("\x00")
+}
+
+func (s *sinkSession) command(line string) error {
+	if _, err := io.WriteString(s.stdin, line); err != nil {
+		return fmt.Errorf("unable to write to SCP sink: %w", err)
+	}
+	return s.readAck()
+}
+
+func (s *sinkSession) readAck() error {
+	code, err := s.stdout.ReadByte()
+	if err != nil {
+		if details := strings.TrimSpace(s.stderr.String()); details != "" {
+			return fmt.Errorf("SCP sink closed unexpectedly: %s", details)
+		}
+		return fmt.Errorf("unable to read SCP acknowledgement: %w", err)
+	}
+
+	switch code {
+	case 0:
+		return nil
+	case 1, 2:
+		msg, _ := s.stdout.ReadString('\n')
+		return &sinkError{msg: strings.TrimSpace(msg), fatal: code == 2}
+	default:
+		return fmt.Errorf("unexpected SCP response byte %#x", code)
+	}
+}
+
+func (s *sinkSession) close() error {
+	s.stdin.Close()
+	err := s.session.Wait()
+	s.session.Close()
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		// The sink exits non-zero after any warning, which has already been
+		// reported against the file that caused it.
+		return nil
+	}
+	return err
+}
+
+func (s *sinkSession) abort() {
+	s.stdin.Close()
+	s.session.Close()
+}
+
+func (b *stderrBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *stderrBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func (e *sinkError) Error() string {
+	return e.msg
+}
@@ -0,0 +1,131 @@
+//go:build unit
+// +build unit
+
+package scp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type nopWriteCloser struct {
+	*bytes.Buffer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func newTestSink(responses string) (*sinkSession, *bytes.Buffer) {
+	var sent bytes.Buffer
+	return &sinkSession{
+		stdin:  nopWriteCloser{&sent},
+		stdout: bufio.NewReader(strings.NewReader(responses)),
+		stderr: &stderrBuffer{},
+	}, &sent
+}
+
+func writeTempFile(t *testing.T, content string, mode os.FileMode) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(p, []byte(content), mode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(p, mode); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestSinkSession_DirectoryStack(t *testing.T) {
+	local := writeTempFile(t, "hello", 0640)
+	sink, sent := newTestSink(strings.Repeat("\x00", 12))
+
+	for _, dirs := range [][]string{{"conf", "nested"}, {"conf", "other"}, nil} {
+		if err := sink.changeDir(dirs); err != nil {
+			t.Fatalf("changeDir(%v): unexpected error: %v", dirs, err)
+		}
+		if err := sink.sendFile(local, "a.txt"); err != nil {
+			t.Fatalf("sendFile: unexpected error: %v", err)
+		}
+	}
+
+	file := "C0640 5 a.txt\nhello\x00"
+	expected := "D0755 0 conf\nD0755 0 nested\n" + file +
+		"E\nD0755 0 other\n" + file +
+		"E\nE\n" + file
+	if sent.String() != expected {
+		t.Errorf("unexpected protocol stream:\n got: %q\nwant: %q", sent.String(), expected)
+	}
+	if len(sink.dirs) != 0 {
+		t.Errorf("expected to be back at the root, still in %v", sink.dirs)
+	}
+}
+
+func TestSinkSession_Warning(t *testing.T) {
+	local := writeTempFile(t, "hello", 0644)
+	sink, sent := newTestSink("\x01scp: /srv/app/a.txt: Permission denied\n")
+
+	err := sink.sendFile(local, "a.txt")
+
+	var sinkErr *sinkError
+	if !errors.As(err, &sinkErr) {
+		t.Fatalf("expected a sinkError, got %v", err)
+	}
+	if sinkErr.fatal || sinkErr.Error() != "scp: /srv/app/a.txt: Permission denied" {
+		t.Errorf("unexpected sink error: %+v", sinkErr)
+	}
+	if strings.Contains(sent.String(), "hello") {
+		t.Error("file content must not be sent after the header is rejected")
+	}
+}
+
+func TestSinkSession_Fatal(t *testing.T) {
+	sink, _ := newTestSink("\x02scp: ambiguous target\n")
+
+	err := sink.changeDir([]string{"conf"})
+
+	var sinkErr *sinkError
+	if !errors.As(err, &sinkErr) || !sinkErr.fatal {
+		t.Fatalf("expected a fatal sinkError, got %v", err)
+	}
+	if len(sink.dirs) != 0 {
+		t.Errorf("expected no directory to be entered, got %v", sink.dirs)
+	}
+}
+
+func TestSinkSession_ClosedStream(t *testing.T) {
+	sink, _ := newTestSink("")
+	sink.stderr.Write([]byte("sh: scp: not found\n"))
+
+	err := sink.readAck()
+	if err == nil || !strings.Contains(err.Error(), "scp: not found") {
+		t.Errorf("expected stderr to be reported, got %v", err)
+	}
+}
+
+func TestRelativeTo(t *testing.T) {
+	tests := []struct {
+		root     string
+		remote   string
+		expected string
+		ok       bool
+	}{
+		{"/srv/app", "/srv/app/docker-compose.yml", "docker-compose.yml", true},
+		{"/srv/app", "/srv/app/conf/nested/a.conf", "conf/nested/a.conf", true},
+		{"/srv/app", "/srv/application/a.conf", "", false},
+		{"/srv/app", "/srv/other/a.conf", "", false},
+		{"/srv/app", "/srv/app", "", false},
+		{"/", "/etc/app.conf", "etc/app.conf", true},
+	}
+
+	for _, tt := range tests {
+		rel, ok := RelativeTo(tt.root, tt.remote)
+		if rel != tt.expected || ok != tt.ok {
+			t.Errorf("RelativeTo(%q, %q) = %q, %v; expected %q, %v", tt.root, tt.remote, rel, ok, tt.expected, tt.ok)
+		}
+	}
+}
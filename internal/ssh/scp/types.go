@@ -0,0 +1,35 @@
+package scp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"golang.org/x/crypto/ssh"
+)
+
+type Uploader struct {
+	cli  *client.Client
+	root string
+	sink *sinkSession
+}
+
+type sinkSession struct {
+	session *ssh.Session
+	stdin   io.WriteCloser
+	stdout  *bufio.Reader
+	stderr  *stderrBuffer
+	dirs    []string
+}
+
+type stderrBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+type sinkError struct {
+	msg   string
+	fatal bool
+}
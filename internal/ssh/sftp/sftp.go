@@ -0,0 +1,95 @@
+package sftp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+type Uploader struct {
+	session *ssh.Session
+	client  *sftp.Client
+}
+
+func NewUploader(cli *client.Client) (*Uploader, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("SSH client is not initialised")
+	}
+
+	session, err := cli.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialise SSH session for SFTP: %w", err)
+	}
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to open stdin pipe for SFTP: %w", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to open stdout pipe for SFTP: %w", err)
+	}
+
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("SFTP subsystem is not available: %w", err)
+	}
+
+	sftpClient, err := sftp.NewClientPipe(stdout, stdin)
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to start SFTP client: %w", err)
+	}
+
+	return &Uploader{session: session, client: sftpClient}, nil
+}
+
+func (u *Uploader) Upload(localPath, remotePath string) error {
+	srcFile, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("unable to open local file '%s': %w", localPath, err)
+	}
+	defer srcFile.Close()
+
+	info, err := srcFile.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve file info for '%s': %w", localPath, err)
+	}
+
+	dir := path.Dir(remotePath)
+	if err := u.client.MkdirAll(dir); err != nil {
+		return fmt.Errorf("unable to create remote directory '%s': %w", dir, err)
+	}
+
+	dstFile, err := u.client.OpenFile(remotePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return fmt.Errorf("unable to open remote file '%s': %w", remotePath, err)
+	}
+
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
+		dstFile.Close()
+		return fmt.Errorf("unable to copy file content for '%s': %w", localPath, err)
+	}
+	if err := dstFile.Close(); err != nil {
+		return fmt.Errorf("unable to finish writing '%s': %w", remotePath, err)
+	}
+
+	if err := u.client.Chmod(remotePath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("unable to set permissions on '%s': %w", remotePath, err)
+	}
+
+	return nil
+}
+
+func (u *Uploader) Close() error {
+	err := u.client.Close()
+	u.session.Close()
+	return err
+}
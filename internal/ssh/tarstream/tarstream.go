@@ -0,0 +1,163 @@
+package tarstream
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/scp"
+	"github.com/alcharra/docker-deploy-action-go/internal/utils"
+)
+
+func NewUploader(cli *client.Client, root string) (*Uploader, error) {
+	if cli == nil {
+		return nil, fmt.Errorf("SSH client is not initialised")
+	}
+	root = path.Clean(root)
+
+	session, err := cli.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialise SSH session for tar transfer: %w", err)
+	}
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to open stdin pipe for tar transfer: %w", err)
+	}
+	stderr := &bytes.Buffer{}
+	session.Stderr = stderr
+
+	cmd := fmt.Sprintf("mkdir -p %s && tar -xzf - --no-same-owner -C %s", utils.ShellQuote(root), utils.ShellQuote(root))
+	if err := session.Start(cmd); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to start tar extraction in '%s': %w", root, err)
+	}
+
+	gz := gzip.NewWriter(stdin)
+	return &Uploader{
+		cli:     cli,
+		root:    root,
+		session: session,
+		stdin:   stdin,
+		stderr:  stderr,
+		gzip:    gz,
+		tar:     tar.NewWriter(gz),
+	}, nil
+}
+
+func (u *Uploader) Upload(localPath, remotePath string) error {
+	rel, ok := scp.RelativeTo(u.root, remotePath)
+	if !ok {
+		// The archive is extracted inside the project path, so anything
+		// outside it is sent on its own.
+		return scp.UploadFileSCP(u.cli, localPath, remotePath)
+	}
+	if u.err != nil {
+		return fmt.Errorf("archive stream is closed: %w", u.err)
+	}
+
+	err := writeEntry(u.tar, localPath, rel)
+	var streamErr *streamError
+	if errors.As(err, &streamErr) {
+		u.err = u.fail(streamErr.err)
+		return u.err
+	}
+	return err
+}
+
+// Batched reports whether remotePath is sent in the archive, and so is only in
+// place once Close succeeds.
+func (u *Uploader) Batched(remotePath string) bool {
+	_, ok := scp.RelativeTo(u.root, remotePath)
+	return ok
+}
+
+func (u *Uploader) Close() error {
+	if u.session == nil {
+		return nil
+	}
+	defer u.session.Close()
+
+	if u.err != nil {
+		// Files archived before the stream broke may not have been extracted.
+		return u.err
+	}
+
+	err := u.tar.Close()
+	if err == nil {
+		err = u.gzip.Close()
+	}
+	if err != nil {
+		return u.fail(err)
+	}
+
+	u.stdin.Close()
+	if err := u.session.Wait(); err != nil {
+		return extractError(u.stderr, err)
+	}
+	return nil
+}
+
+// fail closes the stream and waits for the remote tar to exit so that its own
+// error message can be reported instead of a broken pipe.
+func (u *Uploader) fail(err error) error {
+	u.stdin.Close()
+	u.session.Wait()
+	if strings.TrimSpace(u.stderr.String()) != "" {
+		return extractError(u.stderr, err)
+	}
+	return err
+}
+
+func writeEntry(tw *tar.Writer, localPath, name string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("unable to open local file '%s': %w", localPath, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve file info for '%s': %w", localPath, err)
+	}
+
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(info.Mode().Perm()),
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return &streamError{err}
+	}
+	// Once the header is written a short or failed copy leaves the archive
+	// out of step, so every error from here on ends the stream.
+	if _, err := io.CopyN(tw, file, info.Size()); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = fmt.Errorf("'%s' changed size while being archived", localPath)
+		}
+		return &streamError{err}
+	}
+
+	return nil
+}
+
+func extractError(stderr *bytes.Buffer, err error) error {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("tar extraction failed: %s", msg)
+	}
+	return fmt.Errorf("tar extraction failed: %w", err)
+}
+
+func (e *streamError) Error() string {
+	return e.err.Error()
+}
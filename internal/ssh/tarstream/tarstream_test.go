@@ -0,0 +1,65 @@
+//go:build unit
+// +build unit
+
+package tarstream
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteEntry(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "run.sh")
+	if err := os.WriteFile(local, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(local, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := writeEntry(tw, local, "scripts/deep/run.sh"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(&buf)
+	header, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Name != "scripts/deep/run.sh" {
+		t.Errorf("expected entry name 'scripts/deep/run.sh', got %q", header.Name)
+	}
+	if header.Mode != 0755 {
+		t.Errorf("expected mode 0755, got %o", header.Mode)
+	}
+	content, _ := io.ReadAll(tr)
+	if string(content) != "#!/bin/sh\n" {
+		t.Errorf("unexpected content %q", content)
+	}
+}
+
+func TestWriteEntry_MissingFile(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeEntry(tar.NewWriter(&buf), filepath.Join(t.TempDir(), "missing"), "missing")
+	if err == nil {
+		t.Fatal("expected error for missing file")
+	}
+
+	var streamErr *streamError
+	if errors.As(err, &streamErr) {
+		t.Errorf("a missing local file should not end the stream: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing written to the archive, got %d bytes", buf.Len())
+	}
+}
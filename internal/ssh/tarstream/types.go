@@ -0,0 +1,26 @@
+package tarstream
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	"github.com/alcharra/docker-deploy-action-go/internal/ssh/client"
+	"golang.org/x/crypto/ssh"
+)
+
+type Uploader struct {
+	cli     *client.Client
+	root    string
+	session *ssh.Session
+	stdin   io.WriteCloser
+	stderr  *bytes.Buffer
+	gzip    *gzip.Writer
+	tar     *tar.Writer
+	err     error
+}
+
+type streamError struct {
+	err error
+}
@@ -1,5 +1,11 @@
 package utils
 
+import "strings"
+
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 func Plural(count int) string {
 	if count == 1 {
 		return ""
@@ -11,23 +11,52 @@ import (
 func main() {
 	logs.Step("\U0001F680 Starting deployment...")
 
-	cfg := config.LoadConfig()
-	client := deploy.ConnectToSSH(cfg)
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		logs.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		logs.Fatalf("Invalid configuration: %v", err)
+	}
+
+	if len(cfg.Hosts) > 1 {
+		deploy.DeployToHosts(cfg)
+		logs.Step("\U0001F389 All done — deployment completed successfully on all hosts")
+		return
+	}
+
+	ctx, interrupts := deploy.WatchInterrupts(cfg)
+
+	interrupts.Stage(deploy.StageConnect, cfg)
+	client := deploy.ConnectToSSH(ctx, cfg)
 	defer client.Close()
+	interrupts.Attach(client)
 
+	interrupts.Stage(deploy.StageBackup, cfg)
 	files.BackupDeploymentFiles(client, cfg)
+	interrupts.Stage(deploy.StageUpload, cfg)
 	uploadedFiles := files.UploadFiles(client, cfg)
+	interrupts.Stage(deploy.StageVerify, cfg)
 	files.CheckFilesExistRemote(client, cfg, uploadedFiles)
 
+	interrupts.Stage(deploy.StageDocker, cfg)
 	docker.CheckDockerRequirements(client, &cfg)
+	interrupts.Stage(deploy.StageNetwork, cfg)
 	docker.EnsureDockerNetwork(client, cfg)
+	interrupts.Stage(deploy.StageRegistry, cfg)
 	docker.DockerRegistryLogin(client, cfg)
 
+	interrupts.Stage(deploy.StageDeploy, cfg)
 	docker.DeployDockerStack(client, cfg)
 	docker.DeployDockerCompose(client, cfg)
 
+	interrupts.Stage(deploy.StagePrune, cfg)
 	docker.RunDockerPrune(client, cfg)
+	interrupts.Stage(deploy.StageSync, cfg)
+	files.RemoveStaleFiles(client, cfg, uploadedFiles)
+	interrupts.Stage(deploy.StageCleanup, cfg)
 	deploy.Cleanup(client, cfg)
 
+	interrupts.Finish()
 	logs.Step("\U0001F389 All done — deployment completed successfully")
 }
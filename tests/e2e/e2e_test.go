@@ -16,7 +16,10 @@ import (
 )
 
 func TestDeployBinary_MainDeploy(t *testing.T) {
-	cfg := config.LoadConfig()
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
 
 	binary := "deploy-action"
 	if runtime.GOOS == "windows" {
@@ -44,18 +47,37 @@ func TestDeployBinary_MainDeploy(t *testing.T) {
 		"SSH_USER="+cfg.SSHUser,
 		"SSH_KEY="+cfg.SSHKey,
 		"SSH_KEY_PASSPHRASE="+cfg.SSHKeyPassphrase,
+		"SSH_CERTIFICATE="+cfg.SSHCertificate,
+		"SSH_AUTH_METHODS="+strings.Join(cfg.SSHAuthMethods, "\n"),
 		"SSH_KNOWN_HOSTS="+cfg.SSHKnownHosts,
 		"SSH_FINGERPRINT="+cfg.SSHFingerprint,
+		"SSH_HOST_CA="+cfg.SSHHostCA,
+		"SSH_REVOKED_KEYS="+cfg.SSHRevokedKeys,
 		"SSH_TIMEOUT="+cfg.SSHTimeout,
+		"SSH_CONNECT_RETRIES="+strconv.Itoa(cfg.SSHConnectRetries),
+		"SSH_CONNECT_RETRY_DELAY="+cfg.SSHConnectRetryDelay,
+		"SSH_KEEPALIVE_INTERVAL="+cfg.SSHKeepaliveInterval,
+		"SSH_KEEPALIVE_MAX_MISSED="+strconv.Itoa(cfg.SSHKeepaliveMaxMissed),
 		"PROJECT_PATH="+cfg.ProjectPath,
 		"DEPLOY_FILE="+cfg.DeployFile,
 		"EXTRA_FILES="+strings.Join(extraFilesToEnv(cfg.ExtraFiles), "\n"),
+		"TRANSFER_METHOD="+cfg.TransferMethod,
+		"UPLOAD_CONCURRENCY="+strconv.Itoa(cfg.UploadConcurrency),
+		"SKIP_UNCHANGED="+strconv.FormatBool(cfg.SkipUnchanged),
+		"SYNC_DELETE="+strconv.FormatBool(cfg.SyncDelete),
 		"MODE="+cfg.Mode,
 		"STACK_NAME="+cfg.StackName,
 		"COMPOSE_PULL="+strconv.FormatBool(cfg.ComposePull),
 		"COMPOSE_BUILD="+strconv.FormatBool(cfg.ComposeBuild),
 		"COMPOSE_NO_DEPS="+strconv.FormatBool(cfg.ComposeNoDeps),
 		"COMPOSE_TARGET_SERVICES="+strings.Join(cfg.ComposeTargetServices, "\n"),
+		"COMPOSE_STRATEGY="+cfg.ComposeStrategy,
+		"COMPOSE_HEALTH_TIMEOUT="+cfg.ComposeHealthTimeout,
+		"COMPOSE_PULL_TIMEOUT="+cfg.ComposePullTimeout,
+		"COMPOSE_UP_TIMEOUT="+cfg.ComposeUpTimeout,
+		"STACK_DEPLOY_TIMEOUT="+cfg.StackDeployTimeout,
+		"COMPOSE_LOG_TAIL="+strconv.Itoa(cfg.ComposeLogTail),
+		"STACK_TASK_HISTORY="+strconv.Itoa(cfg.StackTaskHistory),
 		"DOCKER_NETWORK="+cfg.DockerNetwork,
 		"DOCKER_NETWORK_DRIVER="+cfg.DockerNetworkDriver,
 		"DOCKER_NETWORK_ATTACHABLE="+strconv.FormatBool(cfg.DockerNetworkAttach),
@@ -64,8 +86,11 @@ func TestDeployBinary_MainDeploy(t *testing.T) {
 		"REGISTRY_USER="+cfg.RegistryUser,
 		"REGISTRY_PASS="+cfg.RegistryPass,
 		"ENABLE_ROLLBACK="+strconv.FormatBool(cfg.EnableRollback),
+		"ROLLBACK_ON_CANCEL="+strconv.FormatBool(cfg.RollbackOnCancel),
 		"ENV_VARS="+cfg.EnvVars,
 		"VERBOSE="+strconv.FormatBool(cfg.Verbose),
+		"DEPLOY_PARALLELISM="+strconv.Itoa(cfg.DeployParallelism),
+		"FAIL_FAST="+strconv.FormatBool(cfg.FailFast),
 	)
 
 	t.Logf("\U0001F680 Running E2E deploy with: %s", binaryPath)